@@ -1,8 +1,15 @@
 package bigip
 
 import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/scottdware/go-bigip"
 )
@@ -13,14 +20,35 @@ type Config struct {
 	Password       string
 	LoginReference string
 	ConfigOptions  *bigip.ConfigOptions
+	// Partition that names given without one resolve into
+	Partition string
+
+	// TLS settings for the management interface. CABundle, ClientCertificate
+	// and ClientKey are either PEM content or the path of a PEM file
+	Insecure           bool
+	CABundle           string
+	ServerName         string
+	PinnedCertificates []string
+	ClientCertificate  string
+	ClientKey          string
 }
 
 func (c *Config) Client() (*bigip.BigIP, error) {
 
 	if c.Address != "" && c.Username != "" && c.Password != "" {
 		log.Println("[INFO] Initializing BigIP connection")
+		tlsConfig, err := c.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		if c.ConfigOptions == nil {
+			c.ConfigOptions = &bigip.ConfigOptions{
+				APICallTimeout: 60 * time.Second,
+			}
+		}
+		c.ConfigOptions.TLSConfig = tlsConfig
+
 		var client *bigip.BigIP
-		var err error
 		if c.LoginReference != "" {
 			client, err = bigip.NewTokenSession(c.Address, c.Username, c.Password, c.LoginReference, c.ConfigOptions)
 			if err != nil {
@@ -29,6 +57,7 @@ func (c *Config) Client() (*bigip.BigIP, error) {
 		} else {
 			client = bigip.NewSession(c.Address, c.Username, c.Password, c.ConfigOptions)
 		}
+		client.Partition = c.Partition
 		err = c.validateConnection(client)
 		if err == nil {
 			return client, nil
@@ -45,3 +74,77 @@ func (c *Config) validateConnection(client *bigip.BigIP) error {
 	}
 	return nil
 }
+
+// tlsConfig builds the TLS configuration for the management interface. The
+// certificate the BIG-IP presents is verified unless Insecure is set; pinned
+// certificates are checked either way.
+func (c *Config) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: c.Insecure,
+		ServerName:         c.ServerName,
+	}
+
+	if c.CABundle != "" {
+		bundle, err := readPEM(c.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading ca_bundle: %s", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("ca_bundle does not contain any PEM encoded certificates")
+		}
+	}
+
+	if c.ClientCertificate != "" || c.ClientKey != "" {
+		if c.ClientCertificate == "" || c.ClientKey == "" {
+			return nil, fmt.Errorf("client_certificate and client_key must be set together")
+		}
+		cert, err := readPEM(c.ClientCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading client_certificate: %s", err)
+		}
+		key, err := readPEM(c.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading client_key: %s", err)
+		}
+		pair, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading client certificate: %s", err)
+		}
+		config.Certificates = []tls.Certificate{pair}
+	}
+
+	if len(c.PinnedCertificates) > 0 {
+		pins := make(map[string]bool, len(c.PinnedCertificates))
+		for _, pin := range c.PinnedCertificates {
+			pins[normalizeFingerprint(pin)] = true
+		}
+		config.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return fmt.Errorf("BIG-IP did not present a certificate")
+			}
+			sum := sha256.Sum256(rawCerts[0])
+			if fingerprint := hex.EncodeToString(sum[:]); !pins[fingerprint] {
+				return fmt.Errorf("BIG-IP certificate SHA-256 fingerprint %s does not match pinned_certificates", fingerprint)
+			}
+			return nil
+		}
+	}
+
+	return config, nil
+}
+
+// readPEM returns value itself if it is PEM content, otherwise the content of
+// the file it names.
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return ioutil.ReadFile(value)
+}
+
+// normalizeFingerprint strips the colons openssl puts between bytes of a
+// fingerprint and lowercases it, e.g. AB:CD:... => abcd...
+func normalizeFingerprint(fingerprint string) string {
+	return strings.ToLower(strings.Replace(fingerprint, ":", "", -1))
+}
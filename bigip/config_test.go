@@ -0,0 +1,339 @@
+package bigip
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/scottdware/go-bigip"
+)
+
+// newMockBigipTLS serves mock over HTTPS with httptest's certificate, which is
+// valid for example.com and 127.0.0.1.
+func newMockBigipTLS(mock *mockBigip, config *tls.Config) *httptest.Server {
+	srv := httptest.NewUnstartedServer(mock.Config.Handler)
+	srv.TLS = config
+	srv.StartTLS()
+	return srv
+}
+
+func TestConfigTLS(t *testing.T) {
+	mock := newMockBigip("admin", "admin")
+	defer mock.Close()
+
+	srv := newMockBigipTLS(mock, nil)
+	defer srv.Close()
+	ca := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+	fingerprint := strings.Replace(fmt.Sprintf("% X", sha256.Sum256(srv.Certificate().Raw)), " ", ":", -1)
+
+	caFile, err := ioutil.TempFile("", "bigip-ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(caFile.Name())
+	caFile.WriteString(ca)
+	caFile.Close()
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM([]byte(TEST_SSL_CERT_PEM))
+	mtls := newMockBigipTLS(mock, &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	})
+	defer mtls.Close()
+
+	cases := []struct {
+		name   string
+		url    string
+		config Config
+		err    string
+	}{
+		{"verified by default", srv.URL, Config{}, "certificate"},
+		{"insecure", srv.URL, Config{Insecure: true}, ""},
+		{"ca bundle", srv.URL, Config{CABundle: ca}, ""},
+		{"ca bundle file", srv.URL, Config{CABundle: caFile.Name()}, ""},
+		{"missing ca bundle file", srv.URL, Config{CABundle: caFile.Name() + ".missing"}, "Error reading ca_bundle"},
+		{"server name", srv.URL, Config{CABundle: ca, ServerName: "example.com"}, ""},
+		{"wrong server name", srv.URL, Config{CABundle: ca, ServerName: "bigip.example.net"}, "bigip.example.net"},
+		{"pinned", srv.URL, Config{CABundle: ca, PinnedCertificates: []string{fingerprint}}, ""},
+		{"pinned insecure", srv.URL, Config{Insecure: true, PinnedCertificates: []string{"00", fingerprint}}, ""},
+		{"pin mismatch", srv.URL, Config{Insecure: true, PinnedCertificates: []string{fmt.Sprintf("%x", sha256.Sum256(nil))}}, "does not match pinned_certificates"},
+		{"client certificate", mtls.URL, Config{Insecure: true, ClientCertificate: TEST_SSL_CERT_PEM, ClientKey: TEST_SSL_KEY_PEM}, ""},
+		{"no client certificate", mtls.URL, Config{Insecure: true}, "certificate"},
+		{"client certificate without key", mtls.URL, Config{Insecure: true, ClientCertificate: TEST_SSL_CERT_PEM}, "must be set together"},
+	}
+	for _, c := range cases {
+		config := c.config
+		config.Address, config.Username, config.Password = c.url, "admin", "admin"
+		_, err := config.Client()
+		if c.err == "" && err != nil {
+			t.Errorf("%s: unexpected error: %s", c.name, err)
+		}
+		if c.err != "" && (err == nil || !regexp.MustCompile(c.err).MatchString(err.Error())) {
+			t.Errorf("%s: expected error matching %q, got %v", c.name, c.err, err)
+		}
+	}
+}
+
+func TestConfigRetries(t *testing.T) {
+	mock := newMockBigip("admin", "admin")
+	defer mock.Close()
+
+	config := Config{
+		Address:  mock.URL,
+		Username: "admin",
+		Password: "admin",
+		ConfigOptions: &bigip.ConfigOptions{
+			APICallTimeout:  5 * time.Second,
+			Retries:         2,
+			RetryBackoff:    time.Millisecond,
+			MaxRetryBackoff: 2 * time.Millisecond,
+		},
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	busy := mockFault{http.StatusServiceUnavailable, "Service Unavailable"}
+	reset := mockFault{0, ""}
+	cases := []struct {
+		name   string
+		method string
+		faults []mockFault
+		// Faults left unanswered when the call returns
+		pending int
+		err     string
+	}{
+		{"busy", "get", []mockFault{busy, busy}, 0, ""},
+		{"busy too often", "get", []mockFault{busy, busy, busy, busy}, 1, "Service Unavailable"},
+		{"busy post", "post", []mockFault{busy}, 0, ""},
+		{"device busy", "post", []mockFault{{http.StatusBadRequest, "01071038:3: The device is busy"}}, 0, ""},
+		{"other error code", "get", []mockFault{{http.StatusBadRequest, "01020036:3: The requested object is busy"}}, 0, "01020036"},
+		{"busy text", "get", []mockFault{{http.StatusBadRequest, "The system is busy"}}, 0, ""},
+		{"busy text post", "post", []mockFault{{http.StatusBadRequest, "The system is busy"}, busy}, 1, "The system is busy"},
+		{"connection reset", "get", []mockFault{reset}, 0, ""},
+		{"connection reset post", "post", []mockFault{reset, reset}, 1, "EOF"},
+		{"bad gateway", "delete", []mockFault{{http.StatusBadGateway, "Bad Gateway"}}, 0, ""},
+		{"bad gateway post", "post", []mockFault{{http.StatusBadGateway, "Bad Gateway"}}, 0, "Bad Gateway"},
+		{"not found", "get", []mockFault{{http.StatusNotFound, "Not Found"}, busy}, 1, "Not Found"},
+	}
+	for _, c := range cases {
+		mock.fail()
+		req := &bigip.APIRequest{Method: c.method, URL: "ltm/pool", ContentType: "application/json"}
+		if c.method == "delete" {
+			client.CreatePool("retry-pool")
+			req.URL = "ltm/pool/~Common~retry-pool"
+		}
+		if c.method == "post" {
+			req.Body = `{"name":"retry-pool"}`
+			client.DeletePool("retry-pool")
+		}
+		mock.fail(c.faults...)
+		_, err := client.APICall(req)
+		if c.err == "" && err != nil {
+			t.Errorf("%s: unexpected error: %s", c.name, err)
+		}
+		if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+			t.Errorf("%s: expected error containing %q, got %v", c.name, c.err, err)
+		}
+		if pending := mock.pendingFaults(); pending != c.pending {
+			t.Errorf("%s: %d faults left, expected %d", c.name, pending, c.pending)
+		}
+	}
+
+	// A request in a transaction may have been added to it before the
+	// connection was lost, so it isn't sent again
+	mock.fail()
+	client.CreatePool("retry-pool")
+	err = client.Transaction(func(tx *bigip.BigIP) error {
+		mock.fail(reset)
+		_, err := tx.APICall(&bigip.APIRequest{Method: "delete", URL: "ltm/pool/~Common~retry-pool", ContentType: "application/json"})
+		return err
+	})
+	if err == nil || !strings.Contains(err.Error(), "EOF") {
+		t.Errorf("transaction: expected error containing %q, got %v", "EOF", err)
+	}
+}
+
+func TestConfigUncappedRetryBackoff(t *testing.T) {
+	mock := newMockBigip("admin", "admin")
+	defer mock.Close()
+
+	config := Config{
+		Address:  mock.URL,
+		Username: "admin",
+		Password: "admin",
+		ConfigOptions: &bigip.ConfigOptions{
+			APICallTimeout: 5 * time.Second,
+			Retries:        3,
+			RetryBackoff:   10 * time.Millisecond,
+		},
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	busy := mockFault{http.StatusServiceUnavailable, "Service Unavailable"}
+	mock.fail(busy, busy, busy)
+	start := time.Now()
+	if _, err := client.APICall(&bigip.APIRequest{Method: "get", URL: "ltm/pool", ContentType: "application/json"}); err != nil {
+		t.Fatal(err)
+	}
+	// The waits double without a cap: 10ms, 20ms and 40ms
+	if elapsed := time.Since(start); elapsed < 70*time.Millisecond {
+		t.Errorf("retries took %s, expected at least 70ms", elapsed)
+	}
+}
+
+func TestConfigTokenAuth(t *testing.T) {
+	mock := newMockBigip("admin", "admin")
+	defer mock.Close()
+
+	config := Config{
+		Address:        mock.URL,
+		Username:       "admin",
+		Password:       "admin",
+		LoginReference: "tmos",
+		ConfigOptions: &bigip.ConfigOptions{
+			APICallTimeout: 5 * time.Second,
+			TokenTimeout:   time.Hour,
+		},
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := client.Token
+	if timeout := mock.tokenTimeouts()[token]; timeout != 3600 {
+		t.Fatalf("Token %q has timeout %d, expected 3600", token, timeout)
+	}
+
+	mock.expireTokens()
+	if _, err := client.SelfIPs(); err != nil {
+		t.Fatalf("Request with an expired token failed: %s", err)
+	}
+	if client.Token == token {
+		t.Fatalf("Token %s was not replaced after it expired", token)
+	}
+	if timeout := mock.tokenTimeouts()[client.Token]; timeout != 3600 {
+		t.Fatalf("Token %q has timeout %d, expected 3600", client.Token, timeout)
+	}
+
+	if err := client.DeleteToken(); err != nil {
+		t.Fatal(err)
+	}
+	if tokens := mock.tokenTimeouts(); len(tokens) != 0 {
+		t.Fatalf("Tokens %v were not deleted", tokens)
+	}
+	if client.Token != "" {
+		t.Fatalf("Token %s still in use after it was deleted", client.Token)
+	}
+}
+
+func TestClientTransactionTokenRefresh(t *testing.T) {
+	mock := newMockBigip("admin", "admin")
+	defer mock.Close()
+
+	config := Config{
+		Address:        mock.URL,
+		Username:       "admin",
+		Password:       "admin",
+		LoginReference: "tmos",
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := client.Token
+
+	err = client.Transaction(func(tx *bigip.BigIP) error {
+		mock.expireTokens()
+		return tx.CreatePool("tx-token-pool")
+	})
+	if err != nil {
+		t.Fatalf("Transaction with an expired token failed: %s", err)
+	}
+	if client.Token == token || client.Token == "" {
+		t.Fatalf("Token fetched within the transaction was not kept by the session")
+	}
+	if pool, err := client.GetPool("tx-token-pool"); pool == nil || err != nil {
+		t.Fatalf("tx-token-pool was not created (%v)", err)
+	}
+
+	if err := client.DeleteToken(); err != nil {
+		t.Fatal(err)
+	}
+	if tokens := mock.tokenTimeouts(); len(tokens) != 0 {
+		t.Fatalf("Tokens %v were not deleted", tokens)
+	}
+}
+
+func TestClientTransaction(t *testing.T) {
+	mock := newMockBigip("admin", "admin")
+	defer mock.Close()
+
+	config := Config{Address: mock.URL, Username: "admin", Password: "admin"}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.Transaction(func(tx *bigip.BigIP) error {
+		if err := tx.CreatePool("tx-pool"); err != nil {
+			return err
+		}
+		if _, err := tx.GetPool("tx-pool"); err == nil {
+			t.Errorf("Reading tx-pool within the transaction succeeded")
+		}
+		return tx.AddPoolMember("tx-pool", "10.10.10.10:80")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if members, err := client.PoolMembers("tx-pool"); err != nil || len(members) != 1 {
+		t.Fatalf("tx-pool has members %v (%v), expected 10.10.10.10:80", members, err)
+	}
+
+	cases := []struct {
+		name string
+		fn   func(tx *bigip.BigIP) error
+		err  string
+	}{
+		{"aborted", func(tx *bigip.BigIP) error {
+			tx.CreatePool("tx-pool-aborted")
+			return fmt.Errorf("aborted")
+		}, "aborted"},
+		{"failed commit", func(tx *bigip.BigIP) error {
+			tx.CreatePool("tx-pool-failed")
+			tx.DeletePoolMember("tx-pool", "10.10.10.10:80")
+			return tx.ModifyPool("no-such-pool", &bigip.Pool{})
+		}, "transaction failed"},
+	}
+	for _, c := range cases {
+		err := client.Transaction(c.fn)
+		if err == nil || !strings.Contains(err.Error(), c.err) {
+			t.Errorf("%s: expected error containing %q, got %v", c.name, c.err, err)
+		}
+		if pool, err := client.GetPool("tx-pool-" + strings.Fields(c.name)[0]); pool != nil || err != nil {
+			t.Errorf("%s: pool created by the transaction exists (%v)", c.name, err)
+		}
+		if members, err := client.PoolMembers("tx-pool"); err != nil || len(members) != 1 {
+			t.Errorf("%s: tx-pool has members %v (%v), expected 10.10.10.10:80", c.name, members, err)
+		}
+		if open := mock.openTransactions(); open != 0 {
+			t.Errorf("%s: %d transactions left open", c.name, open)
+		}
+	}
+}
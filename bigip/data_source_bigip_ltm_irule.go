@@ -0,0 +1,32 @@
+package bigip
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func dataSourceBigipLtmIRule() *schema.Resource {
+	return &schema.Resource{
+		Read:   dataSourceBigipLtmIRuleRead,
+		Schema: dataSourceSchema(resourceBigipLtmIRule().Schema),
+	}
+}
+
+func dataSourceBigipLtmIRuleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := fullPath(client.Partition, d.Get("name").(string))
+	d.SetId(name)
+
+	exists, err := resourceBigipLtmIRuleExists(d, meta)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("iRule %s not found", name)
+	}
+
+	return resourceBigipLtmIRuleRead(d, meta)
+}
@@ -0,0 +1,33 @@
+package bigip
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+var TEST_IRULE_DATA_SOURCE = TEST_IRULE_RESOURCE + `
+data "bigip_ltm_irule" "test-rule" {
+	name = "${bigip_ltm_irule.test-rule.name}"
+}
+`
+
+func TestBigipLtmIRuleDataSource(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckIRulesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_IRULE_DATA_SOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bigip_ltm_irule.test-rule", "name", TEST_IRULE_NAME),
+					resource.TestMatchResourceAttr("data.bigip_ltm_irule.test-rule", "irule", regexp.MustCompile("^when CLIENT_ACCEPTED")),
+				),
+			},
+		},
+	})
+}
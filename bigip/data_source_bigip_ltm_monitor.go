@@ -0,0 +1,41 @@
+package bigip
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func dataSourceBigipLtmMonitor() *schema.Resource {
+	s := dataSourceSchema(resourceBigipLtmMonitor().Schema)
+	s["type"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		Description:  "Monitor type, e.g. http. Saves searching every type for the monitor",
+		ValidateFunc: validateStringValue(MONITOR_TYPES),
+	}
+
+	return &schema.Resource{
+		Read:   dataSourceBigipLtmMonitorRead,
+		Schema: s,
+	}
+}
+
+func dataSourceBigipLtmMonitorRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := fullPath(client.Partition, d.Get("name").(string))
+	d.SetId(name)
+
+	exists, err := resourceBigipLtmMonitorExists(d, meta)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("Monitor %s not found", name)
+	}
+
+	return resourceBigipLtmMonitorRead(d, meta)
+}
@@ -0,0 +1,34 @@
+package bigip
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+var TEST_MONITOR_DATA_SOURCE = TEST_MONITOR_RESOURCE + `
+data "bigip_ltm_monitor" "test-monitor" {
+	name = "${bigip_ltm_monitor.test-monitor.name}"
+}
+`
+
+func TestBigipLtmMonitorDataSource(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testMonitorsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_MONITOR_DATA_SOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bigip_ltm_monitor.test-monitor", "name", TEST_MONITOR_NAME),
+					resource.TestCheckResourceAttr("data.bigip_ltm_monitor.test-monitor", "parent", "/Common/http"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_monitor.test-monitor", "interval", "999"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_monitor.test-monitor", "receive", "HTTP 1.1 302 Found"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package bigip
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func dataSourceBigipLtmNode() *schema.Resource {
+	return &schema.Resource{
+		Read:   dataSourceBigipLtmNodeRead,
+		Schema: dataSourceSchema(resourceBigipLtmNode().Schema),
+	}
+}
+
+func dataSourceBigipLtmNodeRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := fullPath(client.Partition, d.Get("name").(string))
+	d.SetId(name)
+
+	exists, err := resourceBigipLtmNodeExists(d, meta)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("Node %s not found", name)
+	}
+
+	return resourceBigipLtmNodeRead(d, meta)
+}
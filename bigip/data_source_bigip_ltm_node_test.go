@@ -0,0 +1,32 @@
+package bigip
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+var TEST_NODE_DATA_SOURCE = TEST_NODE_RESOURCE + `
+data "bigip_ltm_node" "test-node" {
+	name = "${bigip_ltm_node.test-node.name}"
+}
+`
+
+func TestBigipLtmNodeDataSource(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckNodesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_NODE_DATA_SOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bigip_ltm_node.test-node", "name", TEST_NODE_NAME),
+					resource.TestCheckResourceAttr("data.bigip_ltm_node.test-node", "address", "10.10.10.10"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,36 @@
+package bigip
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func dataSourceBigipLtmPool() *schema.Resource {
+	return &schema.Resource{
+		Read:   dataSourceBigipLtmPoolRead,
+		Schema: dataSourceSchema(resourceBigipLtmPool().Schema),
+	}
+}
+
+func dataSourceBigipLtmPoolRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := fullPath(client.Partition, d.Get("name").(string))
+	d.SetId(name)
+
+	exists, err := resourceBigipLtmPoolExists(d, meta)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("Pool %s not found", name)
+	}
+
+	err = resourceBigipLtmPoolRead(d, meta)
+	if err != nil {
+		return err
+	}
+	return readPoolNodes(client, d, nil)
+}
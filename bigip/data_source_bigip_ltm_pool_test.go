@@ -0,0 +1,99 @@
+package bigip
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+var TEST_POOL_DATA_SOURCE = TEST_POOL_RESOURCE + `
+data "bigip_ltm_pool" "test-pool" {
+	name = "${bigip_ltm_pool.test-pool.name}"
+}
+`
+
+var TEST_POOL_DATA_SOURCE_MEMBERS = TEST_NODE_RESOURCE + `
+resource "bigip_ltm_pool" "test-pool" {
+	name = "` + TEST_POOL_NAME + `"
+}
+
+resource "bigip_ltm_pool_attachment" "test-member" {
+	pool = "${bigip_ltm_pool.test-pool.name}"
+	node = "${bigip_ltm_node.test-node.name}:80"
+}
+
+data "bigip_ltm_pool" "test-pool" {
+	name = "${bigip_ltm_pool_attachment.test-member.pool}"
+}
+`
+
+var TEST_POOL_DATA_SOURCE_MISSING = `
+data "bigip_ltm_pool" "test-pool" {
+	name = "/` + TEST_PARTITION + `/no-such-pool"
+}
+`
+
+func TestBigipLtmPoolDataSource(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPoolsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_POOL_DATA_SOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bigip_ltm_pool.test-pool", "name", TEST_POOL_NAME),
+					resource.TestCheckResourceAttr("data.bigip_ltm_pool.test-pool", "allow_nat", "true"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_pool.test-pool", "load_balancing_mode", "round-robin"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_pool.test-pool",
+						fmt.Sprintf("monitors.%d", schema.HashString("/Common/http")),
+						"/Common/http"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipLtmPoolDataSource_members(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckPoolsDestroyed,
+			testCheckNodesDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_POOL_DATA_SOURCE_MEMBERS,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bigip_ltm_pool.test-pool", "nodes.#", "1"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_pool.test-pool",
+						fmt.Sprintf("nodes.%d", schema.HashString("test-node:80")),
+						"test-node:80"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipLtmPoolDataSource_missing(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      TEST_POOL_DATA_SOURCE_MISSING,
+				ExpectError: regexp.MustCompile("Pool /" + TEST_PARTITION + "/no-such-pool not found"),
+			},
+		},
+	})
+}
@@ -0,0 +1,109 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+// Profile types the data source reads, by the attribute their settings are
+// returned in, named after the type's resource, e.g. client_ssl for
+// bigip_ltm_profile_client_ssl
+var dataSourceProfileTypes = map[string]func() *profileType{
+	"client_ssl":       profileTypeClientSSL,
+	"server_ssl":       profileTypeServerSSL,
+	"http":             profileTypeHTTP,
+	"tcp":              profileTypeTCP,
+	"fastl4":           profileTypeFastL4,
+	"oneconnect":       profileTypeOneConnect,
+	"http_compression": profileTypeHTTPCompression,
+}
+
+func dataSourceBigipLtmProfile() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"name": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Name of the profile",
+			ValidateFunc: validateF5Name,
+		},
+
+		"parent": &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Profile this profile inherits settings from",
+		},
+	}
+
+	var types []string
+	for attr, profileType := range dataSourceProfileTypes {
+		t := profileType()
+		types = append(types, t.uri)
+
+		settings := dataSourceSchema(resourceBigipLtmProfile(t).Schema)
+		delete(settings, "name")
+		delete(settings, "parent")
+		s[attr] = &schema.Schema{
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Settings of a " + t.description + " profile, as bigip_ltm_profile_" + attr + " takes them",
+			Elem:        &schema.Resource{Schema: settings},
+		}
+	}
+
+	sort.Strings(types)
+	s["type"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		Description:  "Profile type as it appears in the iControl REST path, e.g. http, tcp, client-ssl",
+		ValidateFunc: validateStringValue(types),
+	}
+
+	return &schema.Resource{
+		Read:   dataSourceBigipLtmProfileRead,
+		Schema: s,
+	}
+}
+
+func dataSourceBigipLtmProfileRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := fullPath(client.Partition, d.Get("name").(string))
+
+	for attr, profileType := range dataSourceProfileTypes {
+		t := profileType()
+		if t.uri != d.Get("type").(string) {
+			continue
+		}
+
+		log.Println("[INFO] Reading " + t.description + " profile " + name)
+
+		profile := t.profile()
+		found, err := client.ReadProfile(t.uri, name, profile)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("%s profile %s not found", t.description, name)
+		}
+
+		// Every setting is returned, including those the resource leaves unset
+		// because they are inherited
+		values := resourceBigipLtmProfile(t).Data(nil)
+		t.flatten(values, client, profile)
+		settings := make(map[string]interface{}, len(t.schema))
+		for k := range t.schema {
+			settings[k] = values.Get(k)
+		}
+
+		d.SetId(name)
+		d.Set("parent", values.Get("parent"))
+		d.Set(attr, []interface{}{settings})
+		return nil
+	}
+
+	return fmt.Errorf("Unknown profile type %s", d.Get("type").(string))
+}
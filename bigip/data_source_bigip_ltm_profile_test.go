@@ -0,0 +1,46 @@
+package bigip
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+var TEST_PROFILE_DATA_SOURCE = TEST_CLIENT_SSL_RESOURCE + `
+data "bigip_ltm_profile" "test-client-ssl" {
+	name = "${bigip_ltm_profile_client_ssl.test-client-ssl.name}"
+	type = "client-ssl"
+}
+` + TEST_HTTP_PROFILE_RESOURCE + `
+data "bigip_ltm_profile" "test-http" {
+	name = "${bigip_ltm_profile_http.test-http.name}"
+	type = "http"
+}
+`
+
+func TestBigipLtmProfileDataSource(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckClientSSLProfilesDestroyed,
+			testCheckProfilesDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_PROFILE_DATA_SOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bigip_ltm_profile.test-client-ssl", "name", TEST_CLIENT_SSL_NAME),
+					resource.TestCheckResourceAttr("data.bigip_ltm_profile.test-client-ssl", "parent", "/Common/clientssl"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_profile.test-client-ssl", "client_ssl.#", "1"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_profile.test-client-ssl", "client_ssl.0.cert", "/Common/default.crt"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_profile.test-http", "parent", "/Common/http"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_profile.test-http", "http.0.server_agent_name", "BigIP"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_profile.test-http", "http.0.insert_xforwarded_for", "disabled"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,1376 @@
+package bigip
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/scottdware/go-bigip"
+)
+
+// mockBigip is an in-process stand-in for the iControl REST API. It keeps a
+// stateful, in-memory copy of every collection under /mgmt/tm and answers with
+// the same status codes and error bodies a BIG-IP does, which is enough for
+// go-bigip (and therefore the provider) to run against it unmodified.
+type mockBigip struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	user        string
+	password    string
+	tokens      map[string]int // token => timeout in seconds
+	tokenCount  int
+	collections map[string]*mockCollection
+	uploads     map[string][]byte
+	faults      []mockFault
+
+	transactions     map[string]*mockTransaction
+	transactionCount int64
+}
+
+// A mockTransaction holds the requests queued in it until it is committed.
+type mockTransaction struct {
+	id       int64
+	state    string
+	requests []mockRequest
+}
+
+type mockRequest struct {
+	method string
+	path   string
+	query  string
+	body   []byte
+}
+
+// A mockFault is answered in place of a request, to test how failures are handled.
+type mockFault struct {
+	// Status code to answer with, or 0 to close the connection without answering
+	status  int
+	message string
+}
+
+type mockCollection struct {
+	kind        string
+	partitioned bool
+	items       []*mockItem
+}
+
+type mockItem struct {
+	body map[string]interface{}
+	subs map[string]*mockCollection
+}
+
+// Collections that live three segments deep rather than two, e.g. ltm/monitor/http.
+var mockNestedCollections = map[string]bool{
+	"ltm/monitor":     true,
+	"ltm/profile":     true,
+	"ltm/data-group":  true,
+	"ltm/persistence": true,
+	"sys/crypto":      true,
+	"sys/file":        true,
+}
+
+// Collections whose objects are not created inside a partition.
+var mockUnpartitioned = map[string]bool{
+	"net/interface": true,
+	"net/trunk":     true,
+}
+
+// Array attributes that BIG-IP accepts inline but stores as a subcollection.
+var mockInlineSubcollections = map[string][]string{
+	"ltm/virtual": {"profiles", "policies"},
+	"ltm/pool":    {"members"},
+}
+
+// String attributes BIG-IP trims surrounding whitespace from.
+var mockTrimmedFields = map[string][]string{
+	"ltm/rule": {"apiAnonymous"},
+}
+
+// String attribute values BIG-IP stores under another name.
+var mockRenamedValues = map[string]map[string]map[string]string{
+	"net/route": {"network": {"0.0.0.0/0": "default", "::/0": "default-inet6"}},
+}
+
+// Reference attributes BIG-IP expands to a full /Partition/name path.
+var mockFullPathFields = map[string][]string{
+	"ltm/virtual":               {"destination", "pool", "fallbackPersistence"},
+	"net/self":                  {"vlan", "trafficGroup"},
+	"net/route-domain":          {"vlans"},
+	"ltm/snatpool":              {"members"},
+	"ltm/persistence/universal": {"rule"},
+	"ltm/profile/client-ssl":    {"cert", "key", "chain"},
+	"ltm/profile/server-ssl":    {"cert", "key", "chain"},
+	"*":                         {"defaultsFrom"},
+}
+
+// Boolean flag pairs of which BIG-IP only keeps the one most recently set.
+var mockExclusiveFields = map[string][][2]string{
+	"ltm/virtual": {{"enabled", "disabled"}, {"vlansEnabled", "vlansDisabled"}},
+}
+
+// Attribute values BIG-IP fills in when an object is created without them.
+var mockDefaults = map[string]map[string]interface{}{
+	"ltm/virtual": {
+		"autoLastHop":      "default",
+		"connectionLimit":  0,
+		"enabled":          true,
+		"gtmScore":         0,
+		"mirror":           "disabled",
+		"nat64":            "disabled",
+		"rateLimit":        "disabled",
+		"rateLimitMode":    "object",
+		"rateLimitDstMask": 0,
+		"rateLimitSrcMask": 0,
+		"sourcePort":       "preserve",
+		"synCookieStatus":  "not-activated",
+		"translateAddress": "enabled",
+		"translatePort":    "enabled",
+		"vlansDisabled":    true,
+	},
+	"net/self":         {"trafficGroup": "/Common/traffic-group-local-only"},
+	"net/route-domain": {"strict": "enabled"},
+	"ltm/profile/client-ssl": {
+		"defaultsFrom": "/Common/clientssl",
+		"cert":         "/Common/default.crt",
+		"key":          "/Common/default.key",
+		"chain":        "none",
+		"ciphers":      "DEFAULT",
+		"options":      []interface{}{"dont-insert-empty-fragments"},
+		"serverName":   "none",
+		"sniDefault":   "false",
+		"sniRequire":   "false",
+	},
+	"ltm/profile/server-ssl": {
+		"defaultsFrom": "/Common/serverssl",
+		"cert":         "none",
+		"key":          "none",
+		"chain":        "none",
+		"ciphers":      "DEFAULT",
+		"options":      []interface{}{"dont-insert-empty-fragments"},
+		"serverName":   "none",
+		"sniDefault":   "false",
+		"sniRequire":   "false",
+	},
+	"ltm/persistence/cookie": {
+		"defaultsFrom":            "/Common/cookie",
+		"alwaysSend":              "disabled",
+		"cookieEncryption":        "disabled",
+		"expiration":              "0",
+		"hashLength":              0,
+		"hashOffset":              0,
+		"httponly":                "enabled",
+		"matchAcrossPools":        "disabled",
+		"matchAcrossServices":     "disabled",
+		"matchAcrossVirtuals":     "disabled",
+		"method":                  "insert",
+		"mirror":                  "disabled",
+		"overrideConnectionLimit": "disabled",
+		"secure":                  "enabled",
+		"timeout":                 "180",
+	},
+	"ltm/persistence/source-addr": {
+		"defaultsFrom":            "/Common/source_addr",
+		"hashAlgorithm":           "default",
+		"mapProxies":              "enabled",
+		"mask":                    "none",
+		"matchAcrossPools":        "disabled",
+		"matchAcrossServices":     "disabled",
+		"matchAcrossVirtuals":     "disabled",
+		"mirror":                  "disabled",
+		"overrideConnectionLimit": "disabled",
+		"timeout":                 "180",
+	},
+	"ltm/persistence/dest-addr": {
+		"defaultsFrom":            "/Common/dest_addr",
+		"hashAlgorithm":           "default",
+		"mask":                    "none",
+		"matchAcrossPools":        "disabled",
+		"matchAcrossServices":     "disabled",
+		"matchAcrossVirtuals":     "disabled",
+		"mirror":                  "disabled",
+		"overrideConnectionLimit": "disabled",
+		"timeout":                 "180",
+	},
+	"ltm/persistence/ssl": {
+		"defaultsFrom":            "/Common/ssl",
+		"matchAcrossPools":        "disabled",
+		"matchAcrossServices":     "disabled",
+		"matchAcrossVirtuals":     "disabled",
+		"mirror":                  "disabled",
+		"overrideConnectionLimit": "disabled",
+		"timeout":                 "300",
+	},
+	"ltm/persistence/universal": {
+		"defaultsFrom":            "/Common/universal",
+		"matchAcrossPools":        "disabled",
+		"matchAcrossServices":     "disabled",
+		"matchAcrossVirtuals":     "disabled",
+		"mirror":                  "disabled",
+		"overrideConnectionLimit": "disabled",
+		"rule":                    "none",
+		"timeout":                 "180",
+	},
+	"ltm/profile/http": {
+		"defaultsFrom":              "/Common/http",
+		"acceptXff":                 "disabled",
+		"basicAuthRealm":            "none",
+		"fallbackHost":              "",
+		"insertXforwardedFor":       "disabled",
+		"oneconnectTransformations": "enabled",
+		"redirectRewrite":           "none",
+		"requestChunking":           "preserve",
+		"responseChunking":          "selective",
+		"serverAgentName":           "BigIP",
+	},
+	"ltm/profile/tcp": {
+		"defaultsFrom":      "/Common/tcp",
+		"closeWaitTimeout":  5,
+		"congestionControl": "high-speed",
+		"delayedAcks":       "enabled",
+		"finWaitTimeout":    5,
+		"idleTimeout":       300,
+		"keepAliveInterval": 1800,
+		"nagle":             "enabled",
+		"proxyBufferHigh":   49152,
+		"proxyBufferLow":    32768,
+		"receiveWindowSize": 65535,
+		"selectiveAcks":     "enabled",
+		"sendBufferSize":    65535,
+	},
+	"ltm/profile/fastl4": {
+		"defaultsFrom":        "/Common/fastL4",
+		"idleTimeout":         "300",
+		"lateBinding":         "disabled",
+		"looseClose":          "disabled",
+		"looseInitialization": "disabled",
+		"mssOverride":         0,
+		"pvaAcceleration":     "full",
+		"receiveWindowSize":   0,
+		"resetOnTimeout":      "enabled",
+		"tcpHandshakeTimeout": "5",
+	},
+	"ltm/profile/one-connect": {
+		"defaultsFrom":        "/Common/oneconnect",
+		"idleTimeoutOverride": "disabled",
+		"limitType":           "none",
+		"maxAge":              86400,
+		"maxReuse":            1000,
+		"maxSize":             10000,
+		"sharePools":          "disabled",
+		"sourceMask":          "any",
+	},
+	"ltm/profile/http-compression": {
+		"defaultsFrom":       "/Common/httpcompression",
+		"allowHttp_10":       "disabled",
+		"bufferSize":         4096,
+		"contentTypeInclude": []interface{}{"text/", "application/(xml|x-javascript)"},
+		"cpuSaver":           "enabled",
+		"gzipLevel":          1,
+		"gzipMemoryLevel":    8,
+		"gzipWindowSize":     16,
+		"keepAcceptEncoding": "disabled",
+		"methodPrefer":       "gzip",
+		"minSize":            1024,
+		"varyHeader":         "enabled",
+	},
+	"ltm/node": {
+		"connectionLimit": 0,
+		"dynamicRatio":    1,
+		"ratio":           1,
+		"rateLimit":       "disabled",
+		"monitor":         "default",
+		"session":         "user-enabled",
+		"state":           "unchecked",
+		"fqdn": map[string]interface{}{
+			"addressFamily": "ipv4",
+			"autopopulate":  "disabled",
+			"downInterval":  5,
+			"interval":      "3600",
+		},
+	},
+	"ltm/monitor/http": {
+		"defaultsFrom": "/Common/http",
+		"destination":  "*:*",
+		"interval":     5,
+		"timeout":      16,
+		"send":         "GET /\\r\\n",
+		"upInterval":   0,
+	},
+	"ltm/monitor/tcp-half-open": {
+		"defaultsFrom": "/Common/tcp_half_open",
+		"destination":  "*:*",
+		"interval":     5,
+		"timeout":      16,
+		"upInterval":   0,
+	},
+	"ltm/monitor/external": {
+		"defaultsFrom": "/Common/external",
+		"destination":  "*:*",
+		"interval":     5,
+		"timeout":      16,
+		"upInterval":   0,
+	},
+	"ltm/monitor/dns": {
+		"defaultsFrom":   "/Common/dns",
+		"destination":    "*:*",
+		"interval":       5,
+		"timeout":        16,
+		"upInterval":     0,
+		"qtype":          "a",
+		"acceptRcode":    "no-error",
+		"answerContains": "query-type",
+	},
+	"ltm/monitor/ldap": {
+		"defaultsFrom":        "/Common/ldap",
+		"destination":         "*:*",
+		"interval":            10,
+		"timeout":             31,
+		"upInterval":          0,
+		"security":            "none",
+		"mandatoryAttributes": "no",
+		"chaseReferrals":      "yes",
+	},
+	"ltm/pool": {
+		"allowNat":               "yes",
+		"allowSnat":              "yes",
+		"ignorePersistedWeight":  "disabled",
+		"ipTosToClient":          "pass-through",
+		"ipTosToServer":          "pass-through",
+		"linkQosToClient":        "pass-through",
+		"linkQosToServer":        "pass-through",
+		"loadBalancingMode":      "round-robin",
+		"minActiveMembers":       0,
+		"minUpMembers":           0,
+		"minUpMembersAction":     "failover",
+		"minUpMembersChecking":   "disabled",
+		"queueDepthLimit":        0,
+		"queueOnConnectionLimit": "disabled",
+		"queueTimeLimit":         0,
+		"reselectTries":          0,
+		"serviceDownAction":      "none",
+		"slowRampTime":           10,
+	},
+	"ltm/pool/members": {
+		"connectionLimit": 0,
+		"dynamicRatio":    1,
+		"ratio":           1,
+		"priorityGroup":   0,
+		"rateLimit":       "disabled",
+		"monitor":         "default",
+		"session":         "user-enabled",
+		"state":           "unchecked",
+	},
+	"net/trunk": {
+		"lacp":             "disabled",
+		"lacpMode":         "active",
+		"lacpTimeout":      "long",
+		"distributionHash": "src-dst-ipport",
+	},
+}
+
+// Built-in profiles other than each type's default one, with the settings
+// that differ from the default's.
+var mockBuiltinProfiles = map[string]map[string]map[string]interface{}{
+	"ltm/profile/tcp": {
+		"/Common/tcp-wan-optimized": {
+			"defaultsFrom":      "/Common/tcp",
+			"nagle":             "disabled",
+			"proxyBufferHigh":   131072,
+			"proxyBufferLow":    98304,
+			"receiveWindowSize": 131072,
+			"sendBufferSize":    131072,
+		},
+	},
+}
+
+// Attributes BIG-IP derives from others whenever an object changes.
+var mockDerived = map[string]func(body map[string]interface{}){
+	"ltm/monitor/external": func(body map[string]interface{}) {
+		variables, _ := body["userDefined"].(map[string]interface{})
+		for k, v := range variables {
+			if v == "none" {
+				delete(variables, k)
+			}
+		}
+	},
+	"ltm/node": func(body map[string]interface{}) {
+		if fqdn, _ := body["fqdn"].(map[string]interface{}); fqdn["tmName"] != nil {
+			body["address"] = "any6"
+		}
+	},
+	"net/self": func(body map[string]interface{}) {
+		body["floating"] = "enabled"
+		if strings.HasSuffix(body["trafficGroup"].(string), "/traffic-group-local-only") {
+			body["floating"] = "disabled"
+		}
+	},
+}
+
+// Where the file-transfer endpoint is served and where it puts uploaded files,
+// and where tokens are managed.
+const (
+	mockTokensPath = "shared/authz/tokens/"
+	mockUploadPath = "shared/file-transfer/uploads/"
+	mockUploadDir  = "/var/config/rest/downloads/"
+)
+
+// A mockCryptoInstaller handles a sys crypto install command, adding the
+// uploaded file to a sys file collection with the attributes BIG-IP reads from it.
+type mockCryptoInstaller struct {
+	collection string
+	parse      func(data []byte, passphrase string) (map[string]interface{}, error)
+}
+
+var mockCryptoInstallers = map[string]mockCryptoInstaller{
+	"sys/crypto/cert": {"sys/file/ssl-cert", mockCertificateAttributes},
+	"sys/crypto/key":  {"sys/file/ssl-key", mockKeyAttributes},
+}
+
+func newMockBigip(user, password string) *mockBigip {
+	m := &mockBigip{
+		user:        user,
+		password:    password,
+		tokens:      make(map[string]int),
+		collections: make(map[string]*mockCollection),
+		uploads:     make(map[string][]byte),
+
+		transactions: make(map[string]*mockTransaction),
+	}
+	m.seedProfiles()
+	m.Server = httptest.NewServer(http.HandlerFunc(m.serveHTTP))
+	return m
+}
+
+// seedProfiles adds the built-in profile every profile type inherits from by
+// default, e.g. /Common/http, with the type's default settings, and the other
+// built-in profiles in mockBuiltinProfiles.
+func (m *mockBigip) seedProfiles() {
+	for key, defaults := range mockDefaults {
+		if !strings.HasPrefix(key, "ltm/profile/") {
+			continue
+		}
+		profiles := map[string]map[string]interface{}{
+			defaults["defaultsFrom"].(string): nil,
+		}
+		for name, settings := range mockBuiltinProfiles[key] {
+			profiles[name] = settings
+		}
+		for name, settings := range profiles {
+			body := map[string]interface{}{"name": name}
+			for k, v := range defaults {
+				if k != "defaultsFrom" {
+					body[k] = v
+				}
+			}
+			for k, v := range settings {
+				body[k] = v
+			}
+			if _, err := m.create(key, m.collection(key), body); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+func (m *mockBigip) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.faults) > 0 {
+		fault := m.faults[0]
+		m.faults = m.faults[1:]
+		if fault.status == 0 {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		mockError(w, fault.status, fault.message)
+		return
+	}
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/mgmt/"), "/")
+	if path == "shared/authn/login" && r.Method == "POST" {
+		m.login(w, r)
+		return
+	}
+	if !m.authorized(r) {
+		mockError(w, http.StatusUnauthorized, "Authorization failed: no user authentication header or token detected.")
+		return
+	}
+	if id := r.Header.Get("X-F5-REST-Coordination-Id"); id != "" {
+		m.queue(w, r, path, id)
+		return
+	}
+	if path == "tm/transaction" || strings.HasPrefix(path, "tm/transaction/") {
+		m.serveTransaction(w, r, strings.TrimPrefix(strings.TrimPrefix(path, "tm/transaction"), "/"))
+		return
+	}
+	if strings.HasPrefix(path, mockTokensPath) {
+		m.serveToken(w, r, strings.TrimPrefix(path, mockTokensPath))
+		return
+	}
+	if strings.HasPrefix(path, mockUploadPath) && r.Method == "POST" {
+		m.upload(w, r, strings.TrimPrefix(path, mockUploadPath))
+		return
+	}
+	if path == "tm/util/unix-rm" && r.Method == "POST" {
+		m.unixRm(w, r)
+		return
+	}
+	if !strings.HasPrefix(path, "tm/") {
+		mockError(w, http.StatusNotFound, fmt.Sprintf("Public URI path not registered: /mgmt/%s", path))
+		return
+	}
+	m.serveTM(w, r, strings.Split(strings.TrimPrefix(path, "tm/"), "/"))
+}
+
+// fail answers the next requests with faults, one per request, in place of any
+// faults not yet answered.
+func (m *mockBigip) fail(faults ...mockFault) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.faults = faults
+}
+
+// pendingFaults is the number of faults not yet answered.
+func (m *mockBigip) pendingFaults() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.faults)
+}
+
+func (m *mockBigip) login(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Username          string `json:"username"`
+		Password          string `json:"password"`
+		LoginProviderName string `json:"loginProviderName"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		mockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Username != m.user || req.Password != m.password {
+		mockError(w, http.StatusUnauthorized, "Authentication failed.")
+		return
+	}
+	m.tokenCount++
+	token := fmt.Sprintf("MOCKTOKEN%d", m.tokenCount)
+	m.tokens[token] = 1200
+	mockJSON(w, http.StatusOK, map[string]interface{}{
+		"username":          req.Username,
+		"loginProviderName": req.LoginProviderName,
+		"token": map[string]interface{}{
+			"token":    token,
+			"name":     token,
+			"userName": req.Username,
+			"timeout":  m.tokens[token],
+		},
+	})
+}
+
+// serveToken reads, extends or deletes a token fetched with login.
+func (m *mockBigip) serveToken(w http.ResponseWriter, r *http.Request, token string) {
+	if _, ok := m.tokens[token]; !ok {
+		mockError(w, http.StatusNotFound, fmt.Sprintf("Token %s not found", token))
+		return
+	}
+	switch r.Method {
+	case "GET":
+	case "PATCH":
+		var body struct {
+			Timeout int `json:"timeout"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			mockError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if body.Timeout < 1 || body.Timeout > 36000 {
+			mockError(w, http.StatusBadRequest, "Timeout must be between 1 and 36000 seconds")
+			return
+		}
+		m.tokens[token] = body.Timeout
+	case "DELETE":
+		delete(m.tokens, token)
+		w.WriteHeader(http.StatusOK)
+		return
+	default:
+		mockError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method %s not allowed on token", r.Method))
+		return
+	}
+	mockJSON(w, http.StatusOK, map[string]interface{}{
+		"token":   token,
+		"timeout": m.tokens[token],
+	})
+}
+
+// expireTokens drops every token fetched with login, as if they had all timed out.
+func (m *mockBigip) expireTokens() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens = make(map[string]int)
+}
+
+// tokenTimeouts returns the live tokens and their timeouts.
+func (m *mockBigip) tokenTimeouts() map[string]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tokens := make(map[string]int, len(m.tokens))
+	for k, v := range m.tokens {
+		tokens[k] = v
+	}
+	return tokens
+}
+
+func (m *mockBigip) authorized(r *http.Request) bool {
+	if token := r.Header.Get("X-F5-Auth-Token"); token != "" {
+		_, ok := m.tokens[token]
+		return ok
+	}
+	auth := strings.TrimPrefix(r.Header.Get("Authorization"), "Basic ")
+	creds, err := base64.StdEncoding.DecodeString(auth)
+	return err == nil && string(creds) == m.user+":"+m.password
+}
+
+// upload stores one chunk of a file sent to the file-transfer endpoint. Chunks
+// must arrive in order; a chunk starting at 0 replaces any earlier upload.
+func (m *mockBigip) upload(w http.ResponseWriter, r *http.Request, filename string) {
+	var start, end, total int
+	if _, err := fmt.Sscanf(r.Header.Get("Content-Range"), "%d-%d/%d", &start, &end, &total); err != nil {
+		mockError(w, http.StatusBadRequest, fmt.Sprintf("Invalid Content-Range header: %s", err))
+		return
+	}
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		mockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(data) != end-start+1 || end >= total {
+		mockError(w, http.StatusBadRequest, fmt.Sprintf("Content-Range %d-%d/%d does not match %d bytes of content", start, end, total, len(data)))
+		return
+	}
+
+	path := mockUploadDir + filename
+	existing := m.uploads[path]
+	if start == 0 {
+		existing = nil
+	}
+	if start != len(existing) {
+		mockError(w, http.StatusBadRequest, fmt.Sprintf("Chunk starting at %d received after %d bytes", start, len(existing)))
+		return
+	}
+	m.uploads[path] = append(existing, data...)
+	mockJSON(w, http.StatusOK, map[string]interface{}{
+		"localFilePath":      path,
+		"totalByteCount":     total,
+		"remainingByteCount": total - end - 1,
+	})
+}
+
+// unixRm removes an uploaded file. Like BIG-IP, a file that can't be removed is
+// reported in commandResult rather than with an error status.
+func (m *mockBigip) unixRm(w http.ResponseWriter, r *http.Request) {
+	var body map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		mockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if body["command"] != "run" {
+		mockError(w, http.StatusBadRequest, fmt.Sprintf("Unsupported command: %v", body["command"]))
+		return
+	}
+	file, _ := body["utilCmdArgs"].(string)
+	if _, ok := m.uploads[file]; ok {
+		delete(m.uploads, file)
+	} else {
+		body["commandResult"] = fmt.Sprintf("/bin/rm: cannot remove '%s': No such file or directory\n", file)
+	}
+	mockJSON(w, http.StatusOK, body)
+}
+
+// uploadedFiles is the paths of the files uploaded and not yet removed.
+func (m *mockBigip) uploadedFiles() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	files := make([]string, 0, len(m.uploads))
+	for file := range m.uploads {
+		files = append(files, file)
+	}
+	return files
+}
+
+// install runs a sys crypto install command, replacing any existing object of
+// the same name.
+func (m *mockBigip) install(w http.ResponseWriter, install mockCryptoInstaller, body map[string]interface{}) {
+	if body["command"] != "install" {
+		mockError(w, http.StatusBadRequest, fmt.Sprintf("Unsupported command: %v", body["command"]))
+		return
+	}
+	file, _ := body["from-local-file"].(string)
+	data, ok := m.uploads[file]
+	if !ok {
+		mockError(w, http.StatusBadRequest, fmt.Sprintf("01070712:3: File not found: %s", file))
+		return
+	}
+	passphrase, _ := body["passphrase"].(string)
+	attrs, err := install.parse(data, passphrase)
+	if err != nil {
+		mockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	name, _ := body["name"].(string)
+	coll := m.collection(install.collection)
+	if item := coll.find(mockFullPath(name)); item != nil {
+		coll.remove(item)
+	}
+	attrs["name"] = name
+	attrs["checksum"] = fmt.Sprintf("SHA1:%d:%x", len(data), sha1.Sum(data))
+	if _, err := m.create(install.collection, coll, attrs); err != nil {
+		mockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	mockJSON(w, http.StatusOK, body)
+}
+
+func mockCertificateAttributes(data []byte, passphrase string) (map[string]interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("Certificate file is not in PEM format")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse certificate: %s", err)
+	}
+
+	fingerprint := strings.Replace(fmt.Sprintf("% X", sha256.Sum256(cert.Raw)), " ", ":", -1)
+	attrs := map[string]interface{}{
+		"subject":          cert.Subject.String(),
+		"issuer":           cert.Issuer.String(),
+		"expirationDate":   cert.NotAfter.Unix(),
+		"expirationString": cert.NotAfter.UTC().Format("Jan _2 15:04:05 2006 GMT"),
+		"fingerprint":      "SHA256/" + fingerprint,
+		"serialNumber":     cert.SerialNumber.String(),
+	}
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		attrs["keyType"], attrs["certificateKeySize"] = "rsa-public", pub.N.BitLen()
+	case *ecdsa.PublicKey:
+		attrs["keyType"], attrs["certificateKeySize"] = "ec-public", pub.Params().BitSize
+	}
+	return attrs, nil
+}
+
+func mockKeyAttributes(data []byte, passphrase string) (map[string]interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+		return nil, fmt.Errorf("Key file is not in PEM format")
+	}
+	der, securityType := block.Bytes, "normal"
+	if x509.IsEncryptedPEMBlock(block) {
+		if passphrase == "" {
+			return nil, fmt.Errorf("Key is encrypted but no passphrase was given")
+		}
+		var err error
+		if der, err = x509.DecryptPEMBlock(block, []byte(passphrase)); err != nil {
+			return nil, fmt.Errorf("Key decryption failed: %s", err)
+		}
+		securityType = "password"
+	}
+
+	var key interface{}
+	var err error
+	if key, err = x509.ParsePKCS1PrivateKey(der); err != nil {
+		if key, err = x509.ParsePKCS8PrivateKey(der); err != nil {
+			if key, err = x509.ParseECPrivateKey(der); err != nil {
+				return nil, fmt.Errorf("Unable to parse key: %s", err)
+			}
+		}
+	}
+
+	attrs := map[string]interface{}{"securityType": securityType}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		attrs["keyType"], attrs["keySize"] = "rsa-private", k.N.BitLen()
+	case *ecdsa.PrivateKey:
+		attrs["keyType"], attrs["keySize"] = "ec-private", k.Params().BitSize
+	}
+	return attrs, nil
+}
+
+// queue adds a request to a transaction rather than running it.
+func (m *mockBigip) queue(w http.ResponseWriter, r *http.Request, path, id string) {
+	t, ok := m.transactions[id]
+	if !ok {
+		mockError(w, http.StatusNotFound, fmt.Sprintf("Transaction with ID %s not found", id))
+		return
+	}
+	if t.state != "STARTED" {
+		mockError(w, http.StatusBadRequest, fmt.Sprintf("Transaction %s is %s and cannot be modified", id, t.state))
+		return
+	}
+	if r.Method == "GET" || !strings.HasPrefix(path, "tm/") {
+		mockError(w, http.StatusBadRequest, fmt.Sprintf("%s /mgmt/%s is not supported in a transaction", r.Method, path))
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		mockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	t.requests = append(t.requests, mockRequest{r.Method, path, r.URL.RawQuery, body})
+
+	echo := make(map[string]interface{})
+	json.Unmarshal(body, &echo)
+	mockJSON(w, http.StatusOK, echo)
+}
+
+// serveTransaction starts, commits, reads or discards a transaction. A commit
+// runs every queued request in order, and undoes them all if one fails.
+func (m *mockBigip) serveTransaction(w http.ResponseWriter, r *http.Request, id string) {
+	if id == "" {
+		if r.Method != "POST" {
+			mockError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method %s not allowed on collection", r.Method))
+			return
+		}
+		m.transactionCount++
+		t := &mockTransaction{id: m.transactionCount, state: "STARTED"}
+		m.transactions[fmt.Sprint(t.id)] = t
+		mockJSON(w, http.StatusOK, t.status())
+		return
+	}
+
+	t, ok := m.transactions[id]
+	if !ok {
+		mockError(w, http.StatusNotFound, fmt.Sprintf("Transaction with ID %s not found", id))
+		return
+	}
+	switch r.Method {
+	case "GET":
+		mockJSON(w, http.StatusOK, t.status())
+	case "DELETE":
+		delete(m.transactions, id)
+		w.WriteHeader(http.StatusOK)
+	case "PATCH":
+		var body struct {
+			State string `json:"state"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.State != "VALIDATING" {
+			mockError(w, http.StatusBadRequest, "Transaction state can only be set to VALIDATING")
+			return
+		}
+		if t.state != "STARTED" {
+			mockError(w, http.StatusBadRequest, fmt.Sprintf("Transaction %s is %s and cannot be committed", id, t.state))
+			return
+		}
+
+		saved := m.snapshot()
+		for _, q := range t.requests {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(q.method, "/mgmt/"+q.path+"?"+q.query, bytes.NewReader(q.body))
+			m.serveTM(rec, req, strings.Split(strings.TrimPrefix(q.path, "tm/"), "/"))
+			if rec.Code >= 400 {
+				m.collections = saved
+				t.state = "FAILED"
+				var failure struct {
+					Message string `json:"message"`
+				}
+				json.Unmarshal(rec.Body.Bytes(), &failure)
+				mockError(w, http.StatusBadRequest, "transaction failed:"+failure.Message)
+				return
+			}
+		}
+		t.state = "COMPLETED"
+		mockJSON(w, http.StatusOK, t.status())
+	default:
+		mockError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method %s not allowed on transaction", r.Method))
+	}
+}
+
+func (t *mockTransaction) status() map[string]interface{} {
+	return map[string]interface{}{
+		"transId": t.id,
+		"state":   t.state,
+		"kind":    "tm:transactionstate",
+	}
+}
+
+// openTransactions returns the number of transactions that were started and not
+// discarded.
+func (m *mockBigip) openTransactions() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	open := 0
+	for _, t := range m.transactions {
+		if t.state == "STARTED" {
+			open++
+		}
+	}
+	return open
+}
+
+// snapshot copies every collection, so changes can be undone by restoring it.
+func (m *mockBigip) snapshot() map[string]*mockCollection {
+	collections := make(map[string]*mockCollection, len(m.collections))
+	for k, c := range m.collections {
+		collections[k] = c.copy()
+	}
+	return collections
+}
+
+func (m *mockBigip) serveTM(w http.ResponseWriter, r *http.Request, segs []string) {
+	depth := 2
+	if len(segs) > 1 && mockNestedCollections[segs[0]+"/"+segs[1]] {
+		depth = 3
+	}
+	if len(segs) < depth {
+		mockError(w, http.StatusBadRequest, fmt.Sprintf("Invalid collection path: %s", strings.Join(segs, "/")))
+		return
+	}
+	key := strings.Join(segs[:depth], "/")
+	coll := m.collection(key)
+	rest := segs[depth:]
+
+	// Walk item/subcollection pairs, e.g. ~Common~pool/members/~Common~node:80
+	for len(rest) >= 2 {
+		item := coll.find(rest[0])
+		if item == nil {
+			mockNotFound(w, key, rest[0])
+			return
+		}
+		coll = item.subcollection(coll.kind, rest[1])
+		key += "/" + rest[1]
+		rest = rest[2:]
+	}
+
+	body := make(map[string]interface{})
+	if r.Method == "POST" || r.Method == "PUT" || r.Method == "PATCH" {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			mockError(w, http.StatusBadRequest, fmt.Sprintf("Found invalid JSON body in the request: %s", err))
+			return
+		}
+	}
+
+	if install, ok := mockCryptoInstallers[key]; ok && len(rest) == 0 && r.Method == "POST" {
+		m.install(w, install, body)
+		return
+	}
+
+	if len(rest) == 0 {
+		switch r.Method {
+		case "GET":
+			items := make([]interface{}, 0, len(coll.items))
+			for _, item := range coll.items {
+				items = append(items, item.body)
+			}
+			mockJSON(w, http.StatusOK, map[string]interface{}{
+				"kind":  coll.state("collectionstate"),
+				"items": items,
+			})
+		case "POST":
+			item, err := m.create(key, coll, body)
+			if err != nil {
+				mockError(w, http.StatusConflict, err.Error())
+				return
+			}
+			mockJSON(w, http.StatusOK, item.body)
+		default:
+			mockError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method %s not allowed on collection", r.Method))
+		}
+		return
+	}
+
+	item := coll.find(rest[0])
+	if item == nil {
+		mockNotFound(w, key, rest[0])
+		return
+	}
+	switch r.Method {
+	case "GET":
+		mockJSON(w, http.StatusOK, item.body)
+	case "PUT", "PATCH":
+		if options := r.URL.Query().Get("options"); options != "" {
+			if err := mockRecordOptions(item, options); err != nil {
+				mockError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		m.update(key, coll, item, body)
+		mockJSON(w, http.StatusOK, item.body)
+	case "DELETE":
+		if pool := m.nodePool(key, item); pool != "" {
+			mockError(w, http.StatusBadRequest, fmt.Sprintf("01070110:3: Node address '%s' is referenced by a member of pool '%s'.", item.body["fullPath"], pool))
+			return
+		}
+		coll.remove(item)
+		w.WriteHeader(http.StatusOK)
+	default:
+		mockError(w, http.StatusMethodNotAllowed, fmt.Sprintf("Method %s not allowed on item", r.Method))
+	}
+}
+
+// nodePool returns the full path of a pool with a member on the node item, or
+// "" if there is none or item isn't a node.
+func (m *mockBigip) nodePool(key string, item *mockItem) string {
+	if key != "ltm/node" || m.collections["ltm/pool"] == nil {
+		return ""
+	}
+	for _, pool := range m.collections["ltm/pool"].items {
+		members := pool.subs["members"]
+		if members == nil {
+			continue
+		}
+		for _, member := range members.items {
+			partition, address, _, err := bigip.ParseDestination(member.body["fullPath"].(string))
+			if err == nil && fullPath(partition, address) == item.body["fullPath"] {
+				return pool.body["fullPath"].(string)
+			}
+		}
+	}
+	return ""
+}
+
+func (m *mockBigip) collection(key string) *mockCollection {
+	coll, ok := m.collections[key]
+	if !ok {
+		coll = &mockCollection{
+			kind:        "tm:" + strings.Replace(key, "/", ":", -1),
+			partitioned: !mockUnpartitioned[key],
+		}
+		m.collections[key] = coll
+	}
+	return coll
+}
+
+func (m *mockBigip) create(key string, coll *mockCollection, body map[string]interface{}) (*mockItem, error) {
+	name, _ := body["name"].(string)
+	if name == "" {
+		return nil, fmt.Errorf("The name of the object must be specified")
+	}
+	partition, _ := body["partition"].(string)
+	if p, n := parseF5Identifier(name); p != "" {
+		partition, name = p, n
+	}
+	if partition == "" && coll.partitioned {
+		partition = DEFAULT_PARTITION
+	}
+	fullPath := name
+	if partition != "" {
+		fullPath = fmt.Sprintf("/%s/%s", partition, name)
+		body["partition"] = partition
+	}
+	if coll.find(fullPath) != nil {
+		return nil, fmt.Errorf("01020066:3: The requested object (%s) already exists in partition %s.", name, partition)
+	}
+
+	for k, v := range mockDefaults[key] {
+		if _, ok := body[k]; !ok {
+			body[k] = v
+		}
+	}
+	body["name"] = name
+	body["fullPath"] = fullPath
+	body["kind"] = coll.state("state")
+	body["generation"] = 1
+
+	item := &mockItem{body: make(map[string]interface{}), subs: make(map[string]*mockCollection)}
+	m.apply(key, coll, item, body)
+	coll.items = append(coll.items, item)
+	return item, nil
+}
+
+func (m *mockBigip) update(key string, coll *mockCollection, item *mockItem, body map[string]interface{}) {
+	for _, k := range []string{"name", "partition", "fullPath", "kind", "generation"} {
+		delete(body, k)
+	}
+	if g, ok := item.body["generation"].(int); ok {
+		body["generation"] = g + 1
+	}
+	m.apply(key, coll, item, body)
+}
+
+// apply merges body into the item, splitting out anything BIG-IP keeps as a
+// subcollection and expanding references to their full path.
+func (m *mockBigip) apply(key string, coll *mockCollection, item *mockItem, body map[string]interface{}) {
+	inline := make(map[string]bool)
+	for _, k := range mockInlineSubcollections[key] {
+		inline[k] = true
+	}
+	fullPathFields := append(mockFullPathFields["*"], mockFullPathFields[key]...)
+
+	for _, pair := range mockExclusiveFields[key] {
+		for i, f := range pair {
+			if _, ok := body[f]; ok {
+				delete(item.body, pair[1-i])
+			}
+		}
+	}
+	for k, v := range body {
+		var entries []interface{}
+		switch {
+		case strings.HasSuffix(k, "Reference"):
+			ref, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			entries, _ = ref["items"].([]interface{})
+			k = strings.TrimSuffix(k, "Reference")
+		case inline[k]:
+			entries, _ = v.([]interface{})
+		default:
+			item.body[k] = mockMerge(item.body[k], v)
+			continue
+		}
+
+		sub := &mockCollection{kind: coll.kind + ":" + k}
+		item.subs[k] = sub
+		for _, e := range entries {
+			entry, ok := e.(map[string]interface{})
+			if !ok {
+				entry = map[string]interface{}{"name": fmt.Sprint(e)}
+			}
+			m.create(key+"/"+k, sub, entry)
+		}
+	}
+
+	for _, f := range mockTrimmedFields[key] {
+		if v, ok := item.body[f].(string); ok {
+			item.body[f] = strings.TrimSpace(v)
+		}
+	}
+	for f, names := range mockRenamedValues[key] {
+		if v, ok := item.body[f].(string); ok && names[v] != "" {
+			item.body[f] = names[v]
+		}
+	}
+	for _, f := range fullPathFields {
+		switch v := item.body[f].(type) {
+		case string:
+			item.body[f] = mockFullPath(v)
+		case []interface{}:
+			for i, e := range v {
+				if s, ok := e.(string); ok {
+					v[i] = mockFullPath(s)
+				}
+			}
+		}
+	}
+	if derive, ok := mockDerived[key]; ok {
+		derive(item.body)
+	}
+}
+
+// mockRecordOptions runs the tmsh records add and delete commands a data
+// group modify accepts in its options parameter, e.g.
+// records add { "key" { data "value" } }.
+func mockRecordOptions(item *mockItem, options string) error {
+	tokens := mockTmshTokens(options)
+	if len(tokens) < 4 || tokens[0] != "records" || tokens[2] != "{" || tokens[len(tokens)-1] != "}" {
+		return fmt.Errorf("Unsupported options: %s", options)
+	}
+	records, _ := item.body["records"].([]interface{})
+	index := func(name string) int {
+		for i, r := range records {
+			if r.(map[string]interface{})["name"] == name {
+				return i
+			}
+		}
+		return -1
+	}
+
+	args := tokens[3 : len(tokens)-1]
+	switch tokens[1] {
+	case "add":
+		for len(args) > 0 {
+			if len(args) < 3 || args[1] != "{" {
+				return fmt.Errorf("Unsupported options: %s", options)
+			}
+			record := map[string]interface{}{"name": args[0]}
+			args = args[2:]
+			for len(args) > 0 && args[0] != "}" {
+				if len(args) < 2 || args[0] != "data" {
+					return fmt.Errorf("Unsupported options: %s", options)
+				}
+				if args[1] != "" {
+					record["data"] = args[1]
+				}
+				args = args[2:]
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("Unsupported options: %s", options)
+			}
+			args = args[1:]
+			if index(record["name"].(string)) >= 0 {
+				return fmt.Errorf("01020066:3: The requested data group record (%s) already exists.", record["name"])
+			}
+			records = append(records, record)
+		}
+	case "delete":
+		for _, name := range args {
+			i := index(name)
+			if i < 0 {
+				return fmt.Errorf("01020036:3: The requested data group record (%s) was not found.", name)
+			}
+			records = append(records[:i:i], records[i+1:]...)
+		}
+	default:
+		return fmt.Errorf("Unsupported options: %s", options)
+	}
+	item.body["records"] = records
+	return nil
+}
+
+// mockTmshTokens splits a tmsh command into braces, words and quoted strings.
+func mockTmshTokens(command string) []string {
+	var tokens []string
+	for i := 0; i < len(command); i++ {
+		switch c := command[i]; {
+		case c == ' ':
+		case c == '{' || c == '}':
+			tokens = append(tokens, string(c))
+		case c == '"':
+			var token []byte
+			for i++; i < len(command) && command[i] != '"'; i++ {
+				if command[i] == '\\' && i+1 < len(command) {
+					i++
+				}
+				token = append(token, command[i])
+			}
+			tokens = append(tokens, string(token))
+		default:
+			j := strings.IndexAny(command[i:], " {}")
+			if j < 0 {
+				j = len(command) - i
+			}
+			tokens = append(tokens, command[i:i+j])
+			i += j - 1
+		}
+	}
+	return tokens
+}
+
+// mockMerge returns the new value of an attribute. Like BIG-IP, nested objects
+// are merged so a modify only needs to send the fields that change.
+func mockMerge(old, v interface{}) interface{} {
+	oldObj, ok := old.(map[string]interface{})
+	obj, ok2 := v.(map[string]interface{})
+	if !ok || !ok2 {
+		return v
+	}
+	merged := make(map[string]interface{}, len(oldObj)+len(obj))
+	for k, e := range oldObj {
+		merged[k] = e
+	}
+	for k, e := range obj {
+		merged[k] = e
+	}
+	return merged
+}
+
+// mockFullPath prefixes a bare object name with the default partition.
+func mockFullPath(name string) string {
+	if name == "" || name == "none" || strings.HasPrefix(name, "/") {
+		return name
+	}
+	return fmt.Sprintf("/%s/%s", DEFAULT_PARTITION, name)
+}
+
+// find looks an item up by an iControl path segment such as ~Common~name.
+func (c *mockCollection) find(segment string) *mockItem {
+	id := strings.Replace(segment, "~", "/", -1)
+	for _, item := range c.items {
+		if item.body["fullPath"] == id || item.body["name"] == id {
+			return item
+		}
+	}
+	return nil
+}
+
+// state builds the kind reported for the collection or its items, e.g.
+// tm:ltm:pool:poolstate.
+func (c *mockCollection) state(suffix string) string {
+	return c.kind + ":" + c.kind[strings.LastIndex(c.kind, ":")+1:] + suffix
+}
+
+func (c *mockCollection) copy() *mockCollection {
+	cp := &mockCollection{kind: c.kind, partitioned: c.partitioned}
+	for _, item := range c.items {
+		i := &mockItem{
+			body: mockCopy(item.body).(map[string]interface{}),
+			subs: make(map[string]*mockCollection, len(item.subs)),
+		}
+		for k, sub := range item.subs {
+			i.subs[k] = sub.copy()
+		}
+		cp.items = append(cp.items, i)
+	}
+	return cp
+}
+
+// mockCopy deep copies a decoded JSON value.
+func mockCopy(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		cp := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			cp[k] = mockCopy(e)
+		}
+		return cp
+	case []interface{}:
+		cp := make([]interface{}, len(v))
+		for i, e := range v {
+			cp[i] = mockCopy(e)
+		}
+		return cp
+	}
+	return v
+}
+
+func (c *mockCollection) remove(item *mockItem) {
+	for i, candidate := range c.items {
+		if candidate == item {
+			c.items = append(c.items[:i], c.items[i+1:]...)
+			return
+		}
+	}
+}
+
+func (i *mockItem) subcollection(kind, name string) *mockCollection {
+	sub, ok := i.subs[name]
+	if !ok {
+		sub = &mockCollection{kind: kind + ":" + name}
+		i.subs[name] = sub
+	}
+	return sub
+}
+
+func mockNotFound(w http.ResponseWriter, collection, segment string) {
+	mockError(w, http.StatusNotFound, fmt.Sprintf("01020036:3: The requested %s (%s) was not found.",
+		collection, strings.Replace(segment, "~", "/", -1)))
+}
+
+func mockError(w http.ResponseWriter, code int, message string) {
+	mockJSON(w, code, map[string]interface{}{
+		"code":       code,
+		"message":    message,
+		"errorStack": []string{},
+	})
+}
+
+func mockJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
@@ -1,17 +1,26 @@
 package bigip
 
 import (
+	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/scottdware/go-bigip"
 	"log"
+	"math"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 const DEFAULT_PARTITION = "Common"
 
+var MONITOR_MIN_RULE = regexp.MustCompile("^min (\\d+) of \\{\\s*(.*?)\\s*\\}$")
+
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"address": &schema.Schema{
 				Type:        schema.TypeString,
@@ -31,6 +40,13 @@ func Provider() terraform.ResourceProvider {
 				Description: "The user's password",
 				DefaultFunc: schema.EnvDefaultFunc("BIGIP_PASSWORD", nil),
 			},
+			"partition": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Partition that names given without one, e.g. my-pool rather than /Common/my-pool, belong to",
+				DefaultFunc:  schema.EnvDefaultFunc("BIGIP_PARTITION", DEFAULT_PARTITION),
+				ValidateFunc: validatePartition,
+			},
 			"token_auth": &schema.Schema{
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -45,33 +61,304 @@ func Provider() terraform.ResourceProvider {
 				Description: "Login reference for token authentication (see BIG-IP REST docs for details)",
 				DefaultFunc: schema.EnvDefaultFunc("BIGIP_LOGIN_REF", nil),
 			},
+			"token_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Seconds an authentication token stays valid, up to 36000. The BigIP default of 1200 is kept when 0",
+				ValidateFunc: validateIntRange(0, 36000),
+			},
+			"insecure": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Skip verification of the certificate the BigIP presents",
+				DefaultFunc: schema.EnvDefaultFunc("BIGIP_INSECURE", false),
+			},
+			"ca_bundle": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "CA certificates to verify the BigIP with, as PEM content or the path of a PEM file. The system CAs are used by default",
+				DefaultFunc: schema.EnvDefaultFunc("BIGIP_CA_BUNDLE", ""),
+			},
+			"server_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name expected in the BigIP certificate, if it differs from address",
+				DefaultFunc: schema.EnvDefaultFunc("BIGIP_SERVER_NAME", ""),
+			},
+			"pinned_certificates": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "SHA-256 fingerprints, one of which the BigIP certificate must match",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateSHA256Fingerprint,
+				},
+				Set: schema.HashString,
+			},
+			"client_certificate": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Client certificate to authenticate to the BigIP with, as PEM content or the path of a PEM file",
+				DefaultFunc: schema.EnvDefaultFunc("BIGIP_CLIENT_CERT", ""),
+			},
+			"client_key": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Private key of client_certificate, as PEM content or the path of a PEM file",
+				DefaultFunc: schema.EnvDefaultFunc("BIGIP_CLIENT_KEY", ""),
+			},
+			"timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				Description:  "Seconds to wait for each request to the BigIP",
+				ValidateFunc: validateIntRange(1, math.MaxInt32),
+			},
+			"max_retries": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				Description:  "Times a request is retried when the BigIP is busy or the connection fails",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+			"retry_backoff": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "Seconds to wait before the first retry. The wait doubles for each retry after that",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+			"max_retry_backoff": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				Description:  "Longest wait between retries, in seconds",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"bigip_ltm_virtual_server":  resourceBigipLtmVirtualServer(),
-			"bigip_ltm_node":            resourceBigipLtmNode(),
-			"bigip_ltm_pool":            resourceBigipLtmPool(),
-			"bigip_ltm_monitor":         resourceBigipLtmMonitor(),
-			"bigip_ltm_irule":           resourceBigipLtmIRule(),
-			"bigip_ltm_virtual_address": resourceBigipLtmVirtualAddress(),
-			"bigip_ltm_policy":          resourceBigipLtmPolicy(),
+			"bigip_ltm_virtual_server":                  resourceBigipLtmVirtualServer(),
+			"bigip_ltm_node":                            resourceBigipLtmNode(),
+			"bigip_ltm_pool":                            resourceBigipLtmPool(),
+			"bigip_ltm_pool_attachment":                 resourceBigipLtmPoolAttachment(),
+			"bigip_ltm_monitor":                         resourceBigipLtmMonitor(),
+			"bigip_ltm_irule":                           resourceBigipLtmIRule(),
+			"bigip_ltm_virtual_address":                 resourceBigipLtmVirtualAddress(),
+			"bigip_ltm_policy":                          resourceBigipLtmPolicy(),
+			"bigip_ltm_snatpool":                        resourceBigipLtmSnatPool(),
+			"bigip_ltm_datagroup":                       resourceBigipLtmDataGroup(),
+			"bigip_ltm_profile_client_ssl":              resourceBigipLtmProfileClientSSL(),
+			"bigip_ltm_profile_server_ssl":              resourceBigipLtmProfileServerSSL(),
+			"bigip_ltm_profile_http":                    resourceBigipLtmProfileHTTP(),
+			"bigip_ltm_profile_tcp":                     resourceBigipLtmProfileTCP(),
+			"bigip_ltm_profile_fastl4":                  resourceBigipLtmProfileFastL4(),
+			"bigip_ltm_profile_oneconnect":              resourceBigipLtmProfileOneConnect(),
+			"bigip_ltm_profile_http_compression":        resourceBigipLtmProfileHTTPCompression(),
+			"bigip_ltm_persistence_profile_cookie":      resourceBigipLtmPersistenceProfileCookie(),
+			"bigip_ltm_persistence_profile_source_addr": resourceBigipLtmPersistenceProfileSourceAddr(),
+			"bigip_ltm_persistence_profile_dest_addr":   resourceBigipLtmPersistenceProfileDestAddr(),
+			"bigip_ltm_persistence_profile_ssl":         resourceBigipLtmPersistenceProfileSSL(),
+			"bigip_ltm_persistence_profile_universal":   resourceBigipLtmPersistenceProfileUniversal(),
+			"bigip_net_vlan":                            resourceBigipNetVlan(),
+			"bigip_net_selfip":                          resourceBigipNetSelfIP(),
+			"bigip_net_route":                           resourceBigipNetRoute(),
+			"bigip_net_route_domain":                    resourceBigipNetRouteDomain(),
+			"bigip_net_trunk":                           resourceBigipNetTrunk(),
+			"bigip_sys_ssl_certificate":                 resourceBigipSysSSLCertificate(),
+			"bigip_sys_ssl_key":                         resourceBigipSysSSLKey(),
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"bigip_ltm_pool":    dataSourceBigipLtmPool(),
+			"bigip_ltm_node":    dataSourceBigipLtmNode(),
+			"bigip_ltm_monitor": dataSourceBigipLtmMonitor(),
+			"bigip_ltm_irule":   dataSourceBigipLtmIRule(),
+			"bigip_ltm_profile": dataSourceBigipLtmProfile(),
 		},
+	}
+	revoker := &tokenRevoker{}
+	partition := &namePartition{}
+	for _, r := range p.ResourcesMap {
+		revoker.track(r)
+		if !unpartitionedName(r) {
+			partition.suppressDiff(r, "name")
+		}
+	}
+	for _, r := range p.DataSourcesMap {
+		revoker.track(r)
+	}
+	partition.suppressDiff(p.ResourcesMap["bigip_ltm_virtual_server"], "pool")
+	partition.suppressDiff(p.ResourcesMap["bigip_ltm_pool_attachment"], "pool")
+	partition.suppressDiff(p.ResourcesMap["bigip_ltm_pool_attachment"], "node")
+	p.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		partition.name = d.Get("partition").(string)
+		return providerConfigure(p, revoker, d)
+	}
+	return p
+}
+
+//Partition that names given without one resolve into, once the provider is
+//configured. Names, and references that recreate the object when changed, show no
+//diff when only given with or without their partition, e.g. after an import
+type namePartition struct {
+	name string
+}
 
-		ConfigureFunc: providerConfigure,
+func (p *namePartition) suppressDiff(r *schema.Resource, key string) {
+	if s, ok := r.Schema[key]; ok {
+		s.DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+			return fullPath(p.name, old) == fullPath(p.name, new)
+		}
 	}
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+//Whether a resource names objects that live outside any partition, e.g. a trunk
+func unpartitionedName(r *schema.Resource) bool {
+	s, ok := r.Schema["name"]
+	return ok && s.ValidateFunc != nil &&
+		reflect.ValueOf(s.ValidateFunc).Pointer() == reflect.ValueOf(validateUnpartitionedName).Pointer()
+}
+
+func providerConfigure(p *schema.Provider, revoker *tokenRevoker, d *schema.ResourceData) (interface{}, error) {
 	config := Config{
 		Address:  d.Get("address").(string),
 		Username: d.Get("username").(string),
 		Password: d.Get("password").(string),
+
+		Partition: d.Get("partition").(string),
+
+		Insecure:           d.Get("insecure").(bool),
+		CABundle:           d.Get("ca_bundle").(string),
+		ServerName:         d.Get("server_name").(string),
+		PinnedCertificates: setToStringSlice(d.Get("pinned_certificates").(*schema.Set)),
+		ClientCertificate:  d.Get("client_certificate").(string),
+		ClientKey:          d.Get("client_key").(string),
+
+		ConfigOptions: &bigip.ConfigOptions{
+			APICallTimeout:  time.Duration(d.Get("timeout").(int)) * time.Second,
+			Retries:         d.Get("max_retries").(int),
+			RetryBackoff:    time.Duration(d.Get("retry_backoff").(int)) * time.Second,
+			MaxRetryBackoff: time.Duration(d.Get("max_retry_backoff").(int)) * time.Second,
+			TokenTimeout:    time.Duration(d.Get("token_timeout").(int)) * time.Second,
+		},
 	}
 	if d.Get("token_auth").(bool) {
 		config.LoginReference = d.Get("login_ref").(string)
 	}
 
-	return config.Client()
+	client, err := config.Client()
+	if err != nil {
+		return nil, err
+	}
+	if client.Token != "" {
+		revoker.client = client
+		go func() {
+			<-p.StopContext().Done()
+			revoker.stop()
+		}()
+	}
+	return client, nil
+}
+
+//Revokes the client's token once Terraform stops the provider, rather than
+//leaving it valid until it times out. Terraform only stops the provider on
+//interrupt; after a normal run it ends the process without notice. Revoking
+//waits for running resource operations, so they don't lose their token midway,
+//and operations started after it fail rather than run without the token
+type tokenRevoker struct {
+	sync.Mutex
+	client  *bigip.BigIP
+	running int
+	stopped bool
+	revoked bool
+}
+
+//Count the resource's operations as running while they're called
+func (t *tokenRevoker) track(r *schema.Resource) {
+	wrap := func(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		if f == nil {
+			return nil
+		}
+		return func(d *schema.ResourceData, meta interface{}) error {
+			if err := t.begin(); err != nil {
+				return err
+			}
+			defer t.end()
+			return f(d, meta)
+		}
+	}
+	r.Create = wrap(r.Create)
+	r.Read = wrap(r.Read)
+	r.Update = wrap(r.Update)
+	r.Delete = wrap(r.Delete)
+	if exists := r.Exists; exists != nil {
+		r.Exists = func(d *schema.ResourceData, meta interface{}) (bool, error) {
+			if err := t.begin(); err != nil {
+				return false, err
+			}
+			defer t.end()
+			return exists(d, meta)
+		}
+	}
+	if r.Importer != nil && r.Importer.State != nil {
+		state := r.Importer.State
+		r.Importer.State = func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			if err := t.begin(); err != nil {
+				return nil, err
+			}
+			defer t.end()
+			return state(d, meta)
+		}
+	}
+}
+
+func (t *tokenRevoker) begin() error {
+	t.Lock()
+	defer t.Unlock()
+	if t.revoked {
+		return fmt.Errorf("The BigIP authentication token was deleted because Terraform was interrupted")
+	}
+	t.running++
+	return nil
+}
+
+func (t *tokenRevoker) end() {
+	t.Lock()
+	t.running--
+	client := t.revokable()
+	t.Unlock()
+	t.revoke(client)
+}
+
+func (t *tokenRevoker) stop() {
+	t.Lock()
+	t.stopped = true
+	client := t.revokable()
+	t.Unlock()
+	t.revoke(client)
+}
+
+//The client to revoke the token of, once the provider is stopped and no operation
+//is running, or nil. Callers hold the lock
+func (t *tokenRevoker) revokable() *bigip.BigIP {
+	if t.client == nil || !t.stopped || t.running > 0 || t.revoked {
+		return nil
+	}
+	t.revoked = true
+	return t.client
+}
+
+func (t *tokenRevoker) revoke(client *bigip.BigIP) {
+	if client == nil {
+		return
+	}
+	log.Println("[INFO] Deleting BigIP authentication token")
+	if err := client.DeleteToken(); err != nil {
+		log.Printf("[WARN] Error deleting BigIP authentication token: %s", err)
+	}
 }
 
 //Convert slice of strings to schema.Set
@@ -92,6 +379,15 @@ func setToStringSlice(s *schema.Set) []string {
 	return list
 }
 
+//Convert a list attribute to a slice of strings, keeping its order
+func listToStringSlice(l []interface{}) []string {
+	list := make([]string, len(l))
+	for i, v := range l {
+		list[i] = v.(string)
+	}
+	return list
+}
+
 //Copy map values into an object where map key == object field name (e.g. map[foo] == &{Foo: ...}
 func mapEntity(d map[string]interface{}, obj interface{}) {
 	val := reflect.ValueOf(obj).Elem()
@@ -114,11 +410,145 @@ func mapEntity(d map[string]interface{}, obj interface{}) {
 	}
 }
 
+//Copy a resource schema for use by a data source: name becomes the lookup
+//key and every other attribute is computed from what the BIG-IP returns
+func dataSourceSchema(resourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	s := make(map[string]*schema.Schema, len(resourceSchema))
+	for k, v := range resourceSchema {
+		attr := *v
+		attr.Required = false
+		attr.Optional = false
+		attr.Computed = true
+		attr.ForceNew = false
+		attr.Default = nil
+		attr.DefaultFunc = nil
+		attr.StateFunc = nil
+		attr.ValidateFunc = nil
+		attr.ConflictsWith = nil
+		if elem, ok := v.Elem.(*schema.Resource); ok {
+			attr.Elem = &schema.Resource{Schema: dataSourceSchema(elem.Schema)}
+		}
+		s[k] = &attr
+	}
+	s["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		Description:  resourceSchema["name"].Description,
+		ValidateFunc: validateF5Name,
+	}
+	return s
+}
+
 //Break a string in the format /Partition/name into a Partition / Name object
 func parseF5Identifier(str string) (partition, name string) {
 	if strings.HasPrefix(str, "/") {
 		ary := strings.SplitN(strings.TrimPrefix(str, "/"), "/", 2)
+		if len(ary) < 2 {
+			return ary[0], ""
+		}
 		return ary[0], ary[1]
 	}
 	return "", str
 }
+
+//Qualify a name with partition unless it already has one, e.g. my-pool becomes
+//"/Common/my-pool". DEFAULT_PARTITION is used when partition is empty, and
+//"none", which BigIP uses for an unset reference, is kept as is
+func fullPath(partition, name string) string {
+	if name == "" || name == "none" || strings.HasPrefix(name, "/") {
+		return name
+	}
+	if partition == "" {
+		partition = DEFAULT_PARTITION
+	}
+	return fmt.Sprintf("/%s/%s", partition, name)
+}
+
+//fullPath of each name in a list
+func fullPaths(partition string, names []string) []string {
+	paths := make([]string, len(names))
+	for i, name := range names {
+		paths[i] = fullPath(partition, name)
+	}
+	return paths
+}
+
+//Choose the value to keep in state for a name the BigIP returned. The configured
+//value is kept when it names the same object, so a name given without a partition
+//doesn't show a diff against the full path the BigIP reports
+func configuredPath(partition, configured, actual string) string {
+	if fullPath(partition, configured) == fullPath(partition, actual) {
+		return configured
+	}
+	return actual
+}
+
+//configuredPath of each name in a list
+func configuredPaths(partition string, configured, actual []string) []string {
+	names := make(map[string]string, len(configured))
+	for _, name := range configured {
+		names[fullPath(partition, name)] = name
+	}
+	paths := make([]string, len(actual))
+	for i, name := range actual {
+		paths[i] = name
+		if c, ok := names[fullPath(partition, name)]; ok {
+			paths[i] = c
+		}
+	}
+	return paths
+}
+
+//fullPath of a node or pool member monitor, leaving the default monitor as it is
+func monitorPath(partition, monitor string) string {
+	if monitor == "default" {
+		return monitor
+	}
+	return fullPath(partition, monitor)
+}
+
+//Position of the configured name that names the same object as name, or
+//len(configured) if none does
+func indexOfPath(partition string, configured []string, name string) int {
+	for i, c := range configured {
+		if fullPath(partition, c) == fullPath(partition, name) {
+			return i
+		}
+	}
+	return len(configured)
+}
+
+//Split an address into its IP and route domain, e.g. 10.1.1.1%2 => 10.1.1.1, 2.
+//The route domain is empty when the address does not have one
+func splitRouteDomain(address string) (ip, routeDomain string) {
+	if i := strings.LastIndex(address, "%"); i >= 0 {
+		return address[:i], address[i+1:]
+	}
+	return address, ""
+}
+
+//Build a monitor rule from a list of monitors. With an availability requirement of
+//N the rule is "min N of { a b }", otherwise every monitor must pass: "a and b"
+func makeMonitorRule(monitors []string, min int) (string, error) {
+	if min == 0 {
+		return strings.Join(monitors, " and "), nil
+	}
+	if min > len(monitors) {
+		return "", fmt.Errorf("Availability requirement %d is more than the %d monitors configured", min, len(monitors))
+	}
+	return fmt.Sprintf("min %d of { %s }", min, strings.Join(monitors, " ")), nil
+}
+
+//Break a monitor rule into its monitors and availability requirement (0 when every
+//monitor must pass)
+func parseMonitorRule(rule string) ([]string, int) {
+	rule = strings.TrimSpace(rule)
+	if parts := MONITOR_MIN_RULE.FindStringSubmatch(rule); parts != nil {
+		min, _ := strconv.Atoi(parts[1])
+		return strings.Fields(parts[2]), min
+	}
+	if rule == "" {
+		return []string{}, 0
+	}
+	return strings.Split(rule, " and "), 0
+}
@@ -1,10 +1,17 @@
 package bigip
 
 import (
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/scottdware/go-bigip"
 	"os"
 )
 
@@ -23,6 +30,22 @@ func init() {
 	}
 }
 
+// Unless TF_ACC is set the tests run against an in-process mock of the
+// iControl REST API rather than a real BIG-IP.
+func TestMain(m *testing.M) {
+	if os.Getenv(resource.TestEnvVar) != "" {
+		os.Exit(m.Run())
+	}
+
+	mock := newMockBigip("admin", "admin")
+	os.Setenv("BIGIP_HOST", mock.URL)
+	os.Setenv("BIGIP_USER", "admin")
+	os.Setenv("BIGIP_PASSWORD", "admin")
+	code := m.Run()
+	mock.Close()
+	os.Exit(code)
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -40,3 +63,234 @@ func testAcctPreCheck(t *testing.T) {
 		}
 	}
 }
+
+func TestMonitorRule(t *testing.T) {
+	data := []struct {
+		rule     string
+		monitors []string
+		min      int
+	}{
+		{"", []string{}, 0},
+		{"/Common/http", []string{"/Common/http"}, 0},
+		{"/Common/http and /Common/tcp", []string{"/Common/http", "/Common/tcp"}, 0},
+		{"min 1 of { /Common/http /Common/tcp }", []string{"/Common/http", "/Common/tcp"}, 1},
+		{"min 2 of { /Common/http /Common/tcp /Common/icmp }", []string{"/Common/http", "/Common/tcp", "/Common/icmp"}, 2},
+	}
+
+	for _, d := range data {
+		monitors, min := parseMonitorRule(d.rule)
+		if !reflect.DeepEqual(monitors, d.monitors) || min != d.min {
+			t.Errorf("parseMonitorRule(%q) = %v, %d, expected %v, %d", d.rule, monitors, min, d.monitors, d.min)
+		}
+		rule, err := makeMonitorRule(d.monitors, d.min)
+		if err != nil || rule != d.rule {
+			t.Errorf("makeMonitorRule(%v, %d) = %q, %v, expected %q", d.monitors, d.min, rule, err, d.rule)
+		}
+	}
+
+	if monitors, min := parseMonitorRule(" min 1 of {/Common/http} "); len(monitors) != 1 || min != 1 {
+		t.Errorf("parseMonitorRule did not accept BIG-IP spacing: %v, %d", monitors, min)
+	}
+	if _, err := makeMonitorRule([]string{"/Common/http"}, 2); err == nil {
+		t.Error("makeMonitorRule accepted an availability requirement larger than the monitor count")
+	}
+}
+
+func TestDestination(t *testing.T) {
+	data := []struct {
+		destination string
+		partition   string
+		address     string
+		port        int
+	}{
+		{"/Common/10.1.1.1:80", "Common", "10.1.1.1", 80},
+		{"/Common/10.1.1.1%2:80", "Common", "10.1.1.1%2", 80},
+		{"/my-partition/10.1.1.1:443", "my-partition", "10.1.1.1", 443},
+		{"/Common/app.app/10.1.1.1:443", "Common/app.app", "10.1.1.1", 443},
+		{"/Common/2001:db8::1.443", "Common", "2001:db8::1", 443},
+		{"/Common/2001:db8::1%2.443", "Common", "2001:db8::1%2", 443},
+		{"/Common/0.0.0.0:0", "Common", "0.0.0.0", 0},
+		{"/Common/::.0", "Common", "::", 0},
+		{"/Common/any6.0", "Common", "any6", 0},
+		{"/Common/my-virtual-address:8080", "Common", "my-virtual-address", 8080},
+		{"10.1.1.1:80", "", "10.1.1.1", 80},
+		{"2001:db8::1.80", "", "2001:db8::1", 80},
+	}
+
+	for _, d := range data {
+		partition, address, port, err := bigip.ParseDestination(d.destination)
+		if err != nil || partition != d.partition || address != d.address || port != d.port {
+			t.Errorf("ParseDestination(%q) = %q, %q, %d, %v, expected %q, %q, %d",
+				d.destination, partition, address, port, err, d.partition, d.address, d.port)
+		}
+		if destination := bigip.FormatDestination(d.partition, d.address, d.port); destination != d.destination {
+			t.Errorf("FormatDestination(%q, %q, %d) = %q, expected %q", d.partition, d.address, d.port, destination, d.destination)
+		}
+	}
+
+	for _, d := range []string{"/Common/10.1.1.1:any", "/Common/2001:db8::1.*"} {
+		if _, _, port, err := bigip.ParseDestination(d); err != nil || port != 0 {
+			t.Errorf("ParseDestination(%q) = %d, %v, expected a wildcard port", d, port, err)
+		}
+	}
+	for _, d := range []string{"", "/Common/10.1.1.1", "/Common/10.1.1.1:http", "/Common/10.1.1.1:70000", "/Common/:80"} {
+		if _, _, _, err := bigip.ParseDestination(d); err == nil {
+			t.Errorf("ParseDestination(%q) did not fail", d)
+		}
+	}
+}
+
+func TestPath(t *testing.T) {
+	data := []struct {
+		name      string
+		partition string
+		fullPath  string
+	}{
+		{"my-pool", "", "/Common/my-pool"},
+		{"my-pool", "tenant", "/tenant/my-pool"},
+		{"/Common/my-pool", "tenant", "/Common/my-pool"},
+		{"/Common/app.app/my-pool", "tenant", "/Common/app.app/my-pool"},
+		{"", "tenant", ""},
+		{"none", "tenant", "none"},
+	}
+	for _, d := range data {
+		if path := fullPath(d.partition, d.name); path != d.fullPath {
+			t.Errorf("fullPath(%q, %q) = %q, expected %q", d.partition, d.name, path, d.fullPath)
+		}
+		if path := configuredPath(d.partition, d.name, d.fullPath); path != d.name {
+			t.Errorf("configuredPath(%q, %q, %q) = %q, expected %q", d.partition, d.name, d.fullPath, path, d.name)
+		}
+	}
+
+	if path := configuredPath("tenant", "my-pool", "/Common/my-pool"); path != "/Common/my-pool" {
+		t.Errorf("configuredPath kept my-pool for /Common/my-pool in partition tenant: %q", path)
+	}
+	paths := configuredPaths("tenant", []string{"a", "/Common/b"}, []string{"/tenant/a", "/Common/b", "/tenant/c"})
+	if expected := []string{"a", "/Common/b", "/tenant/c"}; !reflect.DeepEqual(paths, expected) {
+		t.Errorf("configuredPaths = %v, expected %v", paths, expected)
+	}
+
+	for str, expected := range map[string][2]string{
+		"/Common/my-pool":         {"Common", "my-pool"},
+		"/Common/app.app/my-pool": {"Common", "app.app/my-pool"},
+		"/Common":                 {"Common", ""},
+		"my-pool":                 {"", "my-pool"},
+	} {
+		if partition, name := parseF5Identifier(str); partition != expected[0] || name != expected[1] {
+			t.Errorf("parseF5Identifier(%q) = %q, %q, expected %q, %q", str, partition, name, expected[0], expected[1])
+		}
+	}
+}
+
+func TestProviderNameDiffs(t *testing.T) {
+	mock := newMockBigip("admin", "admin")
+	defer mock.Close()
+
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"address":   mock.URL,
+		"username":  "admin",
+		"password":  "admin",
+		"partition": "tenant",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := Provider().(*schema.Provider)
+	if err := p.Configure(terraform.NewResourceConfig(raw)); err != nil {
+		t.Fatal(err)
+	}
+
+	r := p.ResourcesMap["bigip_ltm_pool"]
+	state := &terraform.InstanceState{
+		ID:         "/tenant/my-pool",
+		Attributes: map[string]string{"id": "/tenant/my-pool", "name": "/tenant/my-pool"},
+	}
+	for name, recreate := range map[string]bool{
+		"my-pool":          false,
+		"/tenant/my-pool":  false,
+		"/Common/my-pool":  true,
+		"other-pool":       true,
+		"/tenant/my-pool2": true,
+	} {
+		raw, err := config.NewRawConfig(map[string]interface{}{"name": name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		diff, err := r.Diff(state, terraform.NewResourceConfig(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if requiresNew := diff != nil && diff.RequiresNew(); requiresNew != recreate {
+			t.Errorf("Renaming /tenant/my-pool to %s recreates it: %t, expected %t", name, requiresNew, recreate)
+		}
+	}
+
+	if p.ResourcesMap["bigip_net_trunk"].Schema["name"].DiffSuppressFunc != nil {
+		t.Errorf("Trunk names, which have no partition, have partition diffs suppressed")
+	}
+}
+
+func TestProviderDeletesTokenOnStop(t *testing.T) {
+	mock := newMockBigip("admin", "admin")
+	defer mock.Close()
+
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"address":    mock.URL,
+		"username":   "admin",
+		"password":   "admin",
+		"token_auth": true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := Provider().(*schema.Provider)
+	if err := p.Configure(terraform.NewResourceConfig(raw)); err != nil {
+		t.Fatal(err)
+	}
+	if len(mock.tokenTimeouts()) != 1 {
+		t.Fatalf("Expected the provider to log in with a token")
+	}
+	client := p.Meta().(*bigip.BigIP)
+	if err := client.CreatePool("/Common/stop-pool"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Stop the provider while a read waits to retry a busy BIG-IP
+	r := p.ResourcesMap["bigip_ltm_pool"]
+	d := r.Data(&terraform.InstanceState{ID: "/Common/stop-pool"})
+	mock.fail(mockFault{http.StatusServiceUnavailable, "Service Unavailable"})
+	done := make(chan error)
+	go func() {
+		done <- r.Read(d, client)
+	}()
+	for i := 0; mock.pendingFaults() != 0; i++ {
+		if i == 100 {
+			t.Fatalf("Read didn't reach the BIG-IP")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	p.Stop()
+	time.Sleep(50 * time.Millisecond)
+	if len(mock.tokenTimeouts()) != 1 {
+		t.Fatalf("Token was deleted while a read was running")
+	}
+
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() == "" {
+		t.Fatalf("Read lost the pool")
+	}
+	for i := 0; len(mock.tokenTimeouts()) != 0; i++ {
+		if i == 100 {
+			t.Fatalf("Tokens left after the provider stopped: %v", mock.tokenTimeouts())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Operations started after the token was deleted fail instead of falling
+	// back to the user's password
+	if err := r.Read(d, client); err == nil || !strings.Contains(err.Error(), "interrupted") {
+		t.Fatalf("Read after the token was deleted returned %v, expected an error", err)
+	}
+}
@@ -0,0 +1,229 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipLtmDataGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipLtmDataGroupCreate,
+		Read:   resourceBigipLtmDataGroupRead,
+		Update: resourceBigipLtmDataGroupUpdate,
+		Delete: resourceBigipLtmDataGroupDelete,
+		Exists: resourceBigipLtmDataGroupExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceBigipLtmDataGroupImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the data group",
+				ForceNew:     true,
+				ValidateFunc: validateF5Name,
+			},
+
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Type of the record names: string, ip or integer",
+				ForceNew:     true,
+				ValidateFunc: validateStringValue([]string{"string", "ip", "integer"}),
+			},
+
+			"record": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Records in the data group. May be repeated.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Record name (key). Must match the data group type",
+						},
+						"data": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Value associated with the record",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceBigipLtmDataGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := fullPath(client.Partition, d.Get("name").(string))
+	dgType := d.Get("type").(string)
+
+	records, err := expandDataGroupRecords(dgType, d.Get("record").(*schema.Set))
+	if err != nil {
+		return err
+	}
+
+	log.Println("[INFO] Creating data group " + name)
+	err = client.AddInternalDataGroup(&bigip.DataGroup{
+		Name:    name,
+		Type:    dgType,
+		Records: records,
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId(name)
+
+	return resourceBigipLtmDataGroupRead(d, meta)
+}
+
+func resourceBigipLtmDataGroupRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	log.Println("[INFO] Reading data group " + name)
+
+	dataGroup, err := client.GetInternalDataGroup(name)
+	if err != nil {
+		return err
+	}
+	if dataGroup == nil {
+		log.Println("[WARN] Data group " + name + " not found, removing from state")
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", name)
+	d.Set("type", dataGroup.Type)
+	d.Set("record", flattenDataGroupRecords(dataGroup.Records))
+
+	return nil
+}
+
+func resourceBigipLtmDataGroupExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Checking data group " + name + " exists.")
+
+	dataGroup, err := client.GetInternalDataGroup(name)
+	if err != nil {
+		return false, err
+	}
+
+	if dataGroup == nil {
+		d.SetId("")
+	}
+
+	return dataGroup != nil, nil
+}
+
+func resourceBigipLtmDataGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	if !d.HasChange("record") {
+		return nil
+	}
+
+	dgType := d.Get("type").(string)
+	o, n := d.GetChange("record")
+	oldRecords, newRecords := o.(*schema.Set), n.(*schema.Set)
+	removed, err := expandDataGroupRecords(dgType, oldRecords.Difference(newRecords))
+	if err != nil {
+		return err
+	}
+	added, err := expandDataGroupRecords(dgType, newRecords.Difference(oldRecords))
+	if err != nil {
+		return err
+	}
+
+	// Only the records that changed in the configuration are sent, so records
+	// changed on the BIG-IP since the last refresh aren't overwritten; they show
+	// up as a diff on the next plan instead. A record whose data changed is
+	// deleted and then added back.
+	log.Printf("[INFO] Updating data group %s: deleting %d records, adding %d", name, len(removed), len(added))
+	if len(removed) > 0 {
+		names := make([]string, len(removed))
+		for i, r := range removed {
+			names[i] = r.Name
+		}
+		if err := client.DeleteInternalDataGroupRecords(name, names); err != nil {
+			return err
+		}
+	}
+	if len(added) > 0 {
+		if err := client.AddInternalDataGroupRecords(name, added); err != nil {
+			return err
+		}
+	}
+
+	return resourceBigipLtmDataGroupRead(d, meta)
+}
+
+func resourceBigipLtmDataGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting data group " + name)
+
+	return client.DeleteInternalDataGroup(name)
+}
+
+func resourceBigipLtmDataGroupImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
+	return []*schema.ResourceData{d}, nil
+}
+
+// Convert the record set into data group records, checking each name against the group type
+func expandDataGroupRecords(dgType string, set *schema.Set) ([]bigip.DataGroupRecord, error) {
+	records := make([]bigip.DataGroupRecord, 0, set.Len())
+	for _, r := range set.List() {
+		record := r.(map[string]interface{})
+		name := record["name"].(string)
+
+		switch dgType {
+		case "ip":
+			// BIG-IP stores ip records as networks, so anything else would never match on refresh
+			ip, network, err := net.ParseCIDR(name)
+			if err != nil || !ip.Equal(network.IP) {
+				return nil, fmt.Errorf("Record %q in an ip data group must be a network in CIDR notation, e.g. 10.1.1.0/24 or 10.1.1.1/32", name)
+			}
+		case "integer":
+			if _, err := strconv.Atoi(name); err != nil {
+				return nil, fmt.Errorf("Record %q in an integer data group must be an integer", name)
+			}
+		}
+
+		records = append(records, bigip.DataGroupRecord{
+			Name: name,
+			Data: record["data"].(string),
+		})
+	}
+	return records, nil
+}
+
+// Convert data group records into the record set
+func flattenDataGroupRecords(records []bigip.DataGroupRecord) *schema.Set {
+	s := resourceBigipLtmDataGroup().Schema["record"]
+	set := schema.NewSet(schema.HashResource(s.Elem.(*schema.Resource)), nil)
+	for _, r := range records {
+		set.Add(map[string]interface{}{
+			"name": r.Name,
+			"data": r.Data,
+		})
+	}
+	return set
+}
@@ -0,0 +1,293 @@
+package bigip
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/scottdware/go-bigip"
+)
+
+var TEST_DATAGROUP_NAME = fmt.Sprintf("/%s/test-datagroup", TEST_PARTITION)
+
+var TEST_DATAGROUP_RESOURCE = `
+resource "bigip_ltm_datagroup" "test-datagroup" {
+	name = "` + TEST_DATAGROUP_NAME + `"
+	type = "string"
+	record {
+		name = "/api"
+		data = "api-pool"
+	}
+	record {
+		name = "/static"
+		data = "static-pool"
+	}
+}
+`
+
+var TEST_DATAGROUP_RESOURCE_UPDATED = `
+resource "bigip_ltm_datagroup" "test-datagroup" {
+	name = "` + TEST_DATAGROUP_NAME + `"
+	type = "string"
+	record {
+		name = "/api"
+		data = "api-v2-pool"
+	}
+	record {
+		name = "/static"
+		data = "static-pool"
+	}
+	record {
+		name = "/health"
+	}
+}
+`
+
+var TEST_DATAGROUP_IP_RESOURCE = `
+resource "bigip_ltm_datagroup" "test-datagroup" {
+	name = "` + TEST_DATAGROUP_NAME + `"
+	type = "ip"
+	record {
+		name = "10.0.0.0/8"
+		data = "internal"
+	}
+	record {
+		name = "192.168.1.1/32"
+	}
+}
+`
+
+var TEST_DATAGROUP_IP_RESOURCE_INVALID = `
+resource "bigip_ltm_datagroup" "test-datagroup" {
+	name = "` + TEST_DATAGROUP_NAME + `"
+	type = "ip"
+	record {
+		name = "10.1.1.1/8"
+	}
+}
+`
+
+func TestBigipLtmDataGroup_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckDataGroupsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_DATAGROUP_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckDataGroupExists(TEST_DATAGROUP_NAME, true),
+					testCheckDataGroupRecord(TEST_DATAGROUP_NAME, "/api", "api-pool"),
+					resource.TestCheckResourceAttr("bigip_ltm_datagroup.test-datagroup", "name", TEST_DATAGROUP_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_datagroup.test-datagroup", "type", "string"),
+					resource.TestCheckResourceAttr("bigip_ltm_datagroup.test-datagroup", "record.#", "2"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_DATAGROUP_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckDataGroupRecord(TEST_DATAGROUP_NAME, "/api", "api-v2-pool"),
+					testCheckDataGroupRecord(TEST_DATAGROUP_NAME, "/static", "static-pool"),
+					testCheckDataGroupRecord(TEST_DATAGROUP_NAME, "/health", ""),
+					resource.TestCheckResourceAttr("bigip_ltm_datagroup.test-datagroup", "record.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipLtmDataGroup_ip(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckDataGroupsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      TEST_DATAGROUP_IP_RESOURCE_INVALID,
+				ExpectError: regexp.MustCompile("must be a network in CIDR notation"),
+			},
+			resource.TestStep{
+				Config: TEST_DATAGROUP_IP_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckDataGroupExists(TEST_DATAGROUP_NAME, true),
+					testCheckDataGroupRecord(TEST_DATAGROUP_NAME, "10.0.0.0/8", "internal"),
+					resource.TestCheckResourceAttr("bigip_ltm_datagroup.test-datagroup", "type", "ip"),
+					resource.TestCheckResourceAttr("bigip_ltm_datagroup.test-datagroup", "record.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipLtmDataGroup_import(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckDataGroupsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_DATAGROUP_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckDataGroupExists(TEST_DATAGROUP_NAME, true),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_ltm_datagroup.test-datagroup",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Records changed on the BIG-IP after the plan was made, by anything other
+// than Terraform, are left alone by an update that doesn't touch them.
+func TestBigipLtmDataGroup_updateChangedRecords(t *testing.T) {
+	mock := newMockBigip("admin", "admin")
+	defer mock.Close()
+	client := bigip.NewSession(mock.URL, "admin", "admin", nil)
+
+	name := "/Common/test-datagroup"
+	records := []bigip.DataGroupRecord{{Name: "/api", Data: "api-pool"}, {Name: "/static", Data: "static-pool"}}
+	err := client.AddInternalDataGroup(&bigip.DataGroup{Name: name, Type: "string", Records: records})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := resourceBigipLtmDataGroup()
+	current := r.TestResourceData()
+	current.SetId(name)
+	current.Set("name", name)
+	current.Set("type", "string")
+	current.Set("record", flattenDataGroupRecords(records))
+	state := current.State()
+
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"name": name,
+		"type": "string",
+		"record": []interface{}{
+			map[string]interface{}{"name": "/api", "data": "api-pool"},
+			map[string]interface{}{"name": "/static", "data": "static-pool"},
+			map[string]interface{}{"name": "/health"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff, err := r.Diff(state, terraform.NewResourceConfig(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.ModifyInternalDataGroupRecords(name, &[]bigip.DataGroupRecord{
+		{Name: "/api", Data: "api-v2-pool"},
+		{Name: "/static", Data: "static-pool"},
+		{Name: "/admin", Data: "admin-pool"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.Apply(state, diff, client); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := client.GetInternalDataGroupRecords(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []bigip.DataGroupRecord{
+		{Name: "/api", Data: "api-v2-pool"},
+		{Name: "/static", Data: "static-pool"},
+		{Name: "/admin", Data: "admin-pool"},
+		{Name: "/health"},
+	}
+	if !reflect.DeepEqual(*updated, expected) {
+		t.Errorf("Data group records are %v, expected %v", *updated, expected)
+	}
+}
+
+func TestBigipLtmDataGroup_readMissing(t *testing.T) {
+	mock := newMockBigip("admin", "admin")
+	defer mock.Close()
+	client := bigip.NewSession(mock.URL, "admin", "admin", nil)
+
+	d := resourceBigipLtmDataGroup().TestResourceData()
+	d.SetId("/Common/no-such-datagroup")
+	if err := resourceBigipLtmDataGroupRead(d, client); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "" {
+		t.Errorf("Data group ID is %q after reading a missing data group, expected it removed", d.Id())
+	}
+}
+
+func testCheckDataGroupExists(name string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		dataGroup, err := client.GetInternalDataGroup(name)
+		if err != nil {
+			return err
+		}
+		if exists && dataGroup == nil {
+			return fmt.Errorf("Data group %s does not exist.", name)
+		}
+		if !exists && dataGroup != nil {
+			return fmt.Errorf("Data group %s exists.", name)
+		}
+		return nil
+	}
+}
+
+func testCheckDataGroupRecord(name, record, data string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		records, err := client.GetInternalDataGroupRecords(name)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range *records {
+			if r.Name == record {
+				if r.Data != data {
+					return fmt.Errorf("Record %s in data group %s has data %q", record, name, r.Data)
+				}
+				return nil
+			}
+		}
+
+		return fmt.Errorf("Record %s not found in data group %s", record, name)
+	}
+}
+
+func testCheckDataGroupsDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_ltm_datagroup" {
+			continue
+		}
+
+		name := rs.Primary.ID
+		dataGroup, err := client.GetInternalDataGroup(name)
+		if err != nil {
+			return err
+		}
+		if dataGroup != nil {
+			return fmt.Errorf("Data group %s not destroyed.", name)
+		}
+	}
+	return nil
+}
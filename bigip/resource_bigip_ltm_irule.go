@@ -43,7 +43,7 @@ func resourceBigipLtmIRule() *schema.Resource {
 func resourceBigipLtmIRuleCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*bigip.BigIP)
 
-	name := d.Get("name").(string)
+	name := fullPath(client.Partition, d.Get("name").(string))
 	log.Println("[INFO] Creating iRule " + name)
 
 	client.CreateIRule(name, d.Get("irule").(string))
@@ -101,5 +101,7 @@ func resourceBigipLtmIRuleDelete(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceBigipLtmIRuleImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
 	return []*schema.ResourceData{d}, nil
 }
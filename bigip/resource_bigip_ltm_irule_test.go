@@ -21,7 +21,7 @@ EOF
 	}`
 
 func TestBigipLtmIRule_create(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAcctPreCheck(t)
 		},
@@ -39,7 +39,7 @@ func TestBigipLtmIRule_create(t *testing.T) {
 }
 
 func TestBigipLtmIRule_import(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAcctPreCheck(t)
 		},
@@ -96,7 +96,7 @@ func testCheckIRulesDestroyed(s *terraform.State) error {
 			return nil
 		}
 		if irule != nil {
-			return fmt.Errorf("IRule ", name, " not destroyed.")
+			return fmt.Errorf("IRule %s not destroyed.", name)
 		}
 	}
 	return nil
@@ -3,12 +3,37 @@ package bigip
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/scottdware/go-bigip"
-	"strings"
 )
 
+var MONITOR_TYPES = []string{
+	"http",
+	"https",
+	"icmp",
+	"gateway-icmp",
+	"tcp",
+	"tcp-half-open",
+	"tcp-echo",
+	"udp",
+	"dns",
+	"ldap",
+	"smtp",
+	"sip",
+	"ftp",
+	"imap",
+	"pop3",
+	"mysql",
+	"postgresql",
+	"mssql",
+	"oracle",
+	"radius",
+	"external",
+	"inband",
+}
+
 func resourceBigipLtmMonitor() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceBigipLtmMonitorCreate,
@@ -32,9 +57,32 @@ func resourceBigipLtmMonitor() *schema.Resource {
 			"parent": &schema.Schema{
 				Type:         schema.TypeString,
 				Required:     true,
-				ValidateFunc: validateParent,
+				ValidateFunc: validateF5Name,
+				ForceNew:     true,
+				Description:  "Existing monitor to inherit from, either built in (e.g. /Common/http, /Common/tcp_half_open) or custom",
+			},
+
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
 				ForceNew:     true,
-				Description:  "Existing monitor to inherit from. Must be one of /Common/http, /Common/https, /Common/icmp or /Common/gateway-icmp.",
+				Description:  "Monitor type, e.g. http or tcp-half-open. Looked up from the parent when not set",
+				ValidateFunc: validateStringValue(MONITOR_TYPES),
+			},
+
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User defined description",
+			},
+
+			"destination": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Alias address and port to check instead of the pool member, e.g. *:8080 or 10.1.1.1:80",
+				ValidateFunc: validateMonitorDestination,
 			},
 
 			"interval": &schema.Schema{
@@ -44,6 +92,13 @@ func resourceBigipLtmMonitor() *schema.Resource {
 				Default:     3,
 			},
 
+			"up_interval": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Check interval in seconds while the resource is up. 0 uses interval",
+			},
+
 			"timeout": &schema.Schema{
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -51,10 +106,23 @@ func resourceBigipLtmMonitor() *schema.Resource {
 				Default:     16,
 			},
 
+			"username": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User name for monitors that log in, e.g. http, ldap or mysql",
+			},
+
+			"password": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password for monitors that log in. BIG-IP does not return it, so changes made outside Terraform are not detected",
+			},
+
 			"send": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     "GET /\\r\\n",
+				Computed:    true,
 				Description: "Request string to send.",
 				StateFunc: func(s interface{}) string {
 					return strings.Replace(s.(string), "\r\n", "\\r\\n", -1)
@@ -103,28 +171,170 @@ func resourceBigipLtmMonitor() *schema.Resource {
 				Default:     0,
 				Description: "Time in seconds",
 			},
+
+			"run": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "external: script to run, e.g. /Common/my_script",
+			},
+
+			"args": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "external: command line arguments passed to the script",
+			},
+
+			"variables": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "external: environment variables passed to the script",
+			},
+
+			"qname": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "dns: domain name to query",
+			},
+
+			"qtype": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "dns: record type to query, a or aaaa",
+				ValidateFunc: validateStringValue([]string{"a", "aaaa"}),
+			},
+
+			"accept_rcode": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "dns: response codes that mark the server up, no-error or anything",
+				ValidateFunc: validateStringValue([]string{"no-error", "anything"}),
+			},
+
+			"answer_contains": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "dns: records the answer must contain, query-type, any-type or anything",
+				ValidateFunc: validateStringValue([]string{"query-type", "any-type", "anything"}),
+			},
+
+			"base": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "ldap: search base, e.g. dc=example,dc=com",
+			},
+
+			"filter": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "ldap: search filter, e.g. uid=jdoe",
+			},
+
+			"security": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "ldap: connection security, none, ssl or tls",
+				ValidateFunc: validateStringValue([]string{"none", "ssl", "tls"}),
+			},
+
+			"mandatory_attributes": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "ldap: mark the server down unless the search returns attributes",
+			},
+
+			"chase_referrals": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "ldap: follow referrals returned by the server",
+			},
+
+			"domain": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "smtp: domain sent in the HELO command",
+			},
+
+			"mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "sip: transport, udp, tcp, tls or sips",
+				ValidateFunc: validateStringValue([]string{"udp", "tcp", "tls", "sips"}),
+			},
+
+			"database": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "mysql, postgresql, mssql and oracle: database to connect to",
+			},
+
+			"secret": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "radius: shared secret. BIG-IP does not return it, so changes made outside Terraform are not detected",
+			},
+
+			"nas_ip_address": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "radius: NAS IP address sent in requests",
+				ValidateFunc: validateIPAddress,
+			},
 		},
 	}
 }
 
 func resourceBigipLtmMonitorCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*bigip.BigIP)
-	name := d.Get("name").(string)
-
-	log.Println("[INFO] Creating monitor " + name + " :: " + monitorParent(d.Get("parent").(string)))
+	name := fullPath(client.Partition, d.Get("name").(string))
+	parent := fullPath(client.Partition, d.Get("parent").(string))
+
+	monitorType := d.Get("type").(string)
+	if monitorType == "" {
+		var err error
+		monitorType, err = findMonitorType(client, parent)
+		if err != nil {
+			return err
+		}
+		if monitorType == "" {
+			return fmt.Errorf("Parent monitor %s not found", parent)
+		}
+	}
+	d.Set("type", monitorType)
 
-	client.CreateMonitor(
-		name,
-		monitorParent(d.Get("parent").(string)),
-		d.Get("interval").(int),
-		d.Get("timeout").(int),
-		d.Get("send").(string),
-		d.Get("receive").(string),
-	)
+	log.Println("[INFO] Creating " + monitorType + " monitor " + name + " :: " + parent)
 
 	d.SetId(name)
+	err := client.Transaction(func(tx *bigip.BigIP) error {
+		err := tx.AddMonitorOfType(monitorType, &bigip.Monitor{
+			Name:          name,
+			ParentMonitor: parent,
+		})
+		if err != nil {
+			return err
+		}
+		return resourceBigipLtmMonitorUpdate(d, tx)
+	})
+	if err != nil {
+		d.SetId("")
+		return err
+	}
 
-	resourceBigipLtmMonitorUpdate(d, meta)
 	return resourceBigipLtmMonitorRead(d, meta)
 }
 
@@ -133,28 +343,57 @@ func resourceBigipLtmMonitorRead(d *schema.ResourceData, meta interface{}) error
 
 	name := d.Id()
 
-	monitors, err := client.Monitors()
+	monitorType, err := monitorTypeOf(d, client)
 	if err != nil {
 		return err
 	}
-	for _, m := range monitors {
-		if m.FullPath == name {
-			d.Set("interval", m.Interval)
-			d.Set("timeout", m.Timeout)
-			d.Set("send", m.SendString)
-			d.Set("receive", m.ReceiveString)
-			d.Set("receive_disable", m.ReceiveDisable)
-			d.Set("reverse", m.Reverse)
-			d.Set("transparent", m.Transparent)
-			d.Set("ip_dscp", m.IPDSCP)
-			d.Set("time_until_up", m.TimeUntilUp)
-			d.Set("manual_resume", m.ManualResume)
-			d.Set("parent", m.ParentMonitor)
-			d.Set("name", name)
-			return nil
-		}
+	if monitorType == "" {
+		return fmt.Errorf("Couldn't find monitor %s", name)
+	}
+
+	m, err := client.GetMonitor(name, monitorType)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return fmt.Errorf("Couldn't find monitor %s", name)
 	}
-	return fmt.Errorf("Couldn't find monitor ", name)
+
+	d.Set("type", monitorType)
+	d.Set("description", m.Description)
+	d.Set("destination", m.Destination)
+	d.Set("interval", m.Interval)
+	d.Set("up_interval", m.UpInterval)
+	d.Set("timeout", m.Timeout)
+	d.Set("username", m.Username)
+	d.Set("send", m.SendString)
+	d.Set("receive", m.ReceiveString)
+	d.Set("receive_disable", m.ReceiveDisable)
+	d.Set("reverse", m.Reverse)
+	d.Set("transparent", m.Transparent)
+	d.Set("ip_dscp", m.IPDSCP)
+	d.Set("time_until_up", m.TimeUntilUp)
+	d.Set("manual_resume", m.ManualResume)
+	d.Set("run", m.Run)
+	d.Set("args", m.Args)
+	d.Set("variables", m.Variables)
+	d.Set("qname", m.QName)
+	d.Set("qtype", m.QType)
+	d.Set("accept_rcode", m.AcceptRCode)
+	d.Set("answer_contains", m.AnswerContains)
+	d.Set("base", m.Base)
+	d.Set("filter", m.Filter)
+	d.Set("security", m.Security)
+	d.Set("mandatory_attributes", m.MandatoryAttributes == "yes")
+	d.Set("chase_referrals", m.ChaseReferrals == "yes")
+	d.Set("domain", m.Domain)
+	d.Set("mode", m.Mode)
+	d.Set("database", m.Database)
+	d.Set("nas_ip_address", m.NASIPAddress)
+	d.Set("parent", configuredPath(client.Partition, d.Get("parent").(string), m.ParentMonitor))
+	d.Set("name", name)
+
+	return nil
 }
 
 func resourceBigipLtmMonitorExists(d *schema.ResourceData, meta interface{}) (bool, error) {
@@ -163,18 +402,24 @@ func resourceBigipLtmMonitorExists(d *schema.ResourceData, meta interface{}) (bo
 	name := d.Id()
 	log.Println("[INFO] Fetching monitor " + name)
 
-	monitors, err := client.Monitors()
+	monitorType, err := monitorTypeOf(d, client)
 	if err != nil {
 		return false, err
 	}
+	if monitorType == "" {
+		d.SetId("")
+		return false, nil
+	}
 
-	for _, m := range monitors {
-		if m.FullPath == name {
-			return true, nil
-		}
+	m, err := client.GetMonitor(name, monitorType)
+	if err != nil {
+		return false, err
 	}
 
-	return false, nil
+	if m == nil {
+		d.SetId("")
+	}
+	return m != nil, nil
 }
 
 func resourceBigipLtmMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
@@ -183,8 +428,13 @@ func resourceBigipLtmMonitorUpdate(d *schema.ResourceData, meta interface{}) err
 	name := d.Id()
 
 	m := &bigip.Monitor{
+		Description:    d.Get("description").(string),
+		Destination:    d.Get("destination").(string),
 		Interval:       d.Get("interval").(int),
+		UpInterval:     d.Get("up_interval").(int),
 		Timeout:        d.Get("timeout").(int),
+		Username:       d.Get("username").(string),
+		Password:       d.Get("password").(string),
 		SendString:     d.Get("send").(string),
 		ReceiveString:  d.Get("receive").(string),
 		ReceiveDisable: d.Get("receive_disable").(string),
@@ -193,32 +443,111 @@ func resourceBigipLtmMonitorUpdate(d *schema.ResourceData, meta interface{}) err
 		IPDSCP:         d.Get("ip_dscp").(int),
 		TimeUntilUp:    d.Get("time_until_up").(int),
 		ManualResume:   d.Get("manual_resume").(bool),
+		Run:            d.Get("run").(string),
+		Args:           d.Get("args").(string),
+		Variables:      expandMonitorVariables(d),
+		QName:          d.Get("qname").(string),
+		QType:          d.Get("qtype").(string),
+		AcceptRCode:    d.Get("accept_rcode").(string),
+		AnswerContains: d.Get("answer_contains").(string),
+		Base:           d.Get("base").(string),
+		Filter:         d.Get("filter").(string),
+		Security:       d.Get("security").(string),
+		Domain:         d.Get("domain").(string),
+		Mode:           d.Get("mode").(string),
+		Database:       d.Get("database").(string),
+		Secret:         d.Get("secret").(string),
+		NASIPAddress:   d.Get("nas_ip_address").(string),
 	}
 
-	return client.ModifyMonitor(name, monitorParent(d.Get("parent").(string)), m)
+	// Only ldap monitors accept these, so leave them out unless configured
+	if v, ok := d.GetOk("mandatory_attributes"); ok || d.HasChange("mandatory_attributes") {
+		m.MandatoryAttributes = "no"
+		if v.(bool) {
+			m.MandatoryAttributes = "yes"
+		}
+	}
+	if v, ok := d.GetOk("chase_referrals"); ok || d.HasChange("chase_referrals") {
+		m.ChaseReferrals = "no"
+		if v.(bool) {
+			m.ChaseReferrals = "yes"
+		}
+	}
+
+	return client.ModifyMonitor(name, d.Get("type").(string), m)
 }
 
 func resourceBigipLtmMonitorDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*bigip.BigIP)
 	name := d.Id()
-	parent := monitorParent(d.Get("parent").(string))
-	log.Println("[Info] Deleting monitor " + name + "::" + parent)
-	return client.DeleteMonitor(name, parent)
+	monitorType := d.Get("type").(string)
+	log.Println("[Info] Deleting monitor " + name + "::" + monitorType)
+	return client.DeleteMonitor(name, monitorType)
 }
 
-func validateParent(v interface{}, k string) ([]string, []error) {
-	p := v.(string)
-	if p == "/Common/http" || p == "/Common/https" || p == "/Common/icmp" || p == "/Common/gateway-icmp" || p == "/Common/tcp" {
-		return nil, nil
+func resourceBigipLtmMonitorImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
+
+	monitorType, err := findMonitorType(client, d.Id())
+	if err != nil {
+		return nil, err
 	}
+	if monitorType == "" {
+		return nil, fmt.Errorf("Couldn't find monitor %s", d.Id())
+	}
+	d.Set("type", monitorType)
 
-	return nil, []error{fmt.Errorf("parent must be one of /Common/http, /Common/https, /Common/icmp, /Common/gateway-icmp, or /Common/tcp")}
+	return []*schema.ResourceData{d}, nil
 }
 
-func monitorParent(s string) string {
-	return strings.TrimPrefix(s, "/Common/")
+// The monitor type recorded in state. Falls back to searching every type for
+// monitors created before the type was tracked
+func monitorTypeOf(d *schema.ResourceData, client *bigip.BigIP) (string, error) {
+	if t := d.Get("type").(string); t != "" {
+		return t, nil
+	}
+	return findMonitorType(client, d.Id())
 }
 
-func resourceBigipLtmMonitorImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	return []*schema.ResourceData{d}, nil
+// Find the type of an existing monitor, or "" if there is no such monitor. Built
+// in monitors are named after their type (with _ for -) so only custom monitors
+// need to be looked up
+func findMonitorType(client *bigip.BigIP, name string) (string, error) {
+	if partition, n := parseF5Identifier(name); partition == "Common" {
+		builtin := strings.Replace(n, "_", "-", -1)
+		for _, t := range MONITOR_TYPES {
+			if t == builtin {
+				return t, nil
+			}
+		}
+	}
+
+	for _, t := range MONITOR_TYPES {
+		m, err := client.GetMonitor(name, t)
+		if err != nil {
+			return "", err
+		}
+		if m != nil {
+			return t, nil
+		}
+	}
+	return "", nil
+}
+
+// Environment variables for an external monitor. Variables removed from the
+// configuration are sent as none, which is how BIG-IP deletes them
+func expandMonitorVariables(d *schema.ResourceData) map[string]string {
+	variables := make(map[string]string)
+	o, n := d.GetChange("variables")
+	for k := range o.(map[string]interface{}) {
+		variables[k] = "none"
+	}
+	for k, v := range n.(map[string]interface{}) {
+		variables[k] = v.(string)
+	}
+	if len(variables) == 0 {
+		return nil
+	}
+	return variables
 }
@@ -27,8 +27,90 @@ resource "bigip_ltm_monitor" "test-monitor" {
 }
 `
 
+var TEST_MONITOR_RESOURCE_UPDATED = `
+resource "bigip_ltm_monitor" "test-monitor" {
+	name = "` + TEST_MONITOR_NAME + `"
+	parent = "/Common/http"
+	description = "test monitor"
+	destination = "*:8080"
+	send = "GET /health\r\n"
+	receive = "200 OK"
+	receive_disable = "HTTP/1.1 429"
+	interval = 5
+	up_interval = 30
+	timeout = 16
+	username = "monitor"
+	password = "secret"
+}
+`
+
+var TEST_MONITOR_TYPES_RESOURCE = TEST_MONITOR_RESOURCE + `
+resource "bigip_ltm_monitor" "test-half-open" {
+	name = "/` + TEST_PARTITION + `/test-half-open"
+	parent = "/Common/tcp_half_open"
+	destination = "*:443"
+}
+
+resource "bigip_ltm_monitor" "test-custom" {
+	name = "/` + TEST_PARTITION + `/test-custom"
+	parent = "${bigip_ltm_monitor.test-monitor.name}"
+	receive = "HTTP/1.1 200"
+}
+
+resource "bigip_ltm_monitor" "test-external" {
+	name = "/` + TEST_PARTITION + `/test-external"
+	parent = "/Common/external"
+	run = "/Common/check_app"
+	args = "-v"
+	variables {
+		URI = "/health"
+		EXPECT = "ok"
+	}
+}
+
+resource "bigip_ltm_monitor" "test-dns" {
+	name = "/` + TEST_PARTITION + `/test-dns"
+	parent = "/Common/dns"
+	qname = "www.example.com"
+	qtype = "aaaa"
+	accept_rcode = "anything"
+}
+`
+
+var TEST_MONITOR_TYPES_RESOURCE_UPDATED = TEST_MONITOR_RESOURCE + `
+resource "bigip_ltm_monitor" "test-half-open" {
+	name = "/` + TEST_PARTITION + `/test-half-open"
+	parent = "/Common/tcp_half_open"
+	destination = "*:8443"
+}
+
+resource "bigip_ltm_monitor" "test-custom" {
+	name = "/` + TEST_PARTITION + `/test-custom"
+	parent = "${bigip_ltm_monitor.test-monitor.name}"
+	receive = "HTTP/1.1 204"
+}
+
+resource "bigip_ltm_monitor" "test-external" {
+	name = "/` + TEST_PARTITION + `/test-external"
+	parent = "/Common/external"
+	run = "/Common/check_app"
+	args = "-v"
+	variables {
+		URI = "/status"
+	}
+}
+
+resource "bigip_ltm_monitor" "test-dns" {
+	name = "/` + TEST_PARTITION + `/test-dns"
+	parent = "/Common/dns"
+	qname = "www.example.com"
+	qtype = "a"
+	accept_rcode = "anything"
+}
+`
+
 func TestBigipLtmMonitor_create(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAcctPreCheck(t)
 		},
@@ -50,14 +132,78 @@ func TestBigipLtmMonitor_create(t *testing.T) {
 					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-monitor", "manual_resume", "false"),
 					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-monitor", "ip_dscp", "0"),
 					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-monitor", "time_until_up", "0"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-monitor", "type", "http"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-monitor", "destination", "*:*"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_MONITOR_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckMonitorExists(TEST_MONITOR_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-monitor", "description", "test monitor"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-monitor", "destination", "*:8080"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-monitor", "send", "GET /health\\r\\n"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-monitor", "up_interval", "30"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-monitor", "username", "monitor"),
 				),
 			},
 		},
 	})
 }
 
+func TestBigipLtmMonitor_types(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testMonitorsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_MONITOR_TYPES_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckMonitorExists("/"+TEST_PARTITION+"/test-half-open"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-half-open", "type", "tcp-half-open"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-half-open", "destination", "*:443"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-custom", "type", "http"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-custom", "parent", TEST_MONITOR_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-external", "type", "external"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-external", "run", "/Common/check_app"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-external", "args", "-v"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-external", "variables.%", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-external", "variables.URI", "/health"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-dns", "type", "dns"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-dns", "qname", "www.example.com"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-dns", "qtype", "aaaa"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-dns", "answer_contains", "query-type"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_MONITOR_TYPES_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-half-open", "destination", "*:8443"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-custom", "receive", "HTTP/1.1 204"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-external", "variables.%", "1"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-external", "variables.URI", "/status"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-dns", "qtype", "a"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_ltm_monitor.test-custom",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_ltm_monitor.test-external",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestBigipLtmMonitor_import(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAcctPreCheck(t)
 		},
@@ -91,7 +237,7 @@ func testCheckMonitorExists(name string) resource.TestCheckFunc {
 				return nil
 			}
 		}
-		return fmt.Errorf("Monitor ", name, " was not created.")
+		return fmt.Errorf("Monitor %s was not created.", name)
 	}
 }
 
@@ -111,7 +257,7 @@ func testMonitorsDestroyed(s *terraform.State) error {
 		name := rs.Primary.ID
 		for _, m := range monitors {
 			if m.FullPath == name {
-				return fmt.Errorf("Monitor ", name, " not destroyed.")
+				return fmt.Errorf("Monitor %s not destroyed.", name)
 			}
 		}
 	}
@@ -1,19 +1,25 @@
 package bigip
 
 import (
+	"fmt"
 	"log"
-
+	"math"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/scottdware/go-bigip"
 )
 
+// Error BIG-IP returns when deleting a node that pool members are on, naming one of the pools
+var NODE_REFERENCE = regexp.MustCompile("referenced by a member of pool '([^']+)'")
+
 func resourceBigipLtmNode() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceBigipLtmNodeCreate,
 		Read:   resourceBigipLtmNodeRead,
-		//Update: resourceBigipLtmNodeUpdate,
+		Update: resourceBigipLtmNodeUpdate,
 		Delete: resourceBigipLtmNodeDelete,
 		Exists: resourceBigipLtmNodeExists,
 		Importer: &schema.ResourceImporter{
@@ -30,14 +36,119 @@ func resourceBigipLtmNode() *schema.Resource {
 			},
 
 			"address": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Address of the node. May include a route domain, e.g. 10.1.1.1%2. Required unless fqdn is set",
+				ForceNew:     true,
+				ValidateFunc: validateIPAddress,
+			},
+
+			"fqdn": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Resolve the node address from DNS instead of setting address",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							ForceNew:    true,
+							Description: "Fully qualified domain name of the node",
+						},
+						"address_family": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "ipv4",
+							ForceNew:     true,
+							Description:  "ipv4 or ipv6",
+							ValidateFunc: validateStringValue([]string{"ipv4", "ipv6"}),
+						},
+						"autopopulate": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							ForceNew:    true,
+							Description: "Create an ephemeral node for every address the name resolves to",
+						},
+						"interval": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "3600",
+							Description: "Seconds between DNS queries, or ttl to follow the record's TTL",
+						},
+						"down_interval": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      5,
+							Description:  "Seconds between DNS queries while the name does not resolve",
+							ValidateFunc: validateIntRange(1, 3600),
+						},
+					},
+				},
+			},
+
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User defined description",
+			},
+
+			"connection_limit": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Maximum concurrent connections to the node. 0 means unlimited",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+
+			"rate_limit": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Maximum new connections per second to the node. 0 means unlimited",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+
+			"ratio": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "Ratio weight used by ratio load balancing modes",
+				ValidateFunc: validateIntRange(1, 65535),
+			},
+
+			"dynamic_ratio": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "Ratio weight used by dynamic ratio load balancing modes",
+				ValidateFunc: validateIntRange(1, 65535),
+			},
+
+			"monitor": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Address of the node",
-				ForceNew:    true,
-				//ValidateFunc: TODO: validate valid IP address format
+				Optional:    true,
+				Default:     "default",
+				Description: "Health monitor for the node: default, none or a monitor name, e.g. /Common/icmp. Separate several monitors with and",
+			},
+
+			"availability_requirement": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Number of monitors that must pass for the node to be up. 0 means all of them",
+				ValidateFunc: validateIntRange(0, 65535),
 			},
 
-			//TODO: more fields!
+			"state": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "enabled",
+				Description:  "enabled, disabled (only persistent and active connections) or forced-offline (only active connections)",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled", "forced-offline"}),
+			},
 		},
 	}
 }
@@ -45,19 +156,37 @@ func resourceBigipLtmNode() *schema.Resource {
 func resourceBigipLtmNodeCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*bigip.BigIP)
 
-	name := d.Get("name").(string)
+	name := fullPath(client.Partition, d.Get("name").(string))
 	address := d.Get("address").(string)
+	fqdn := expandNodeFQDN(d.Get("fqdn").([]interface{}))
 
-	log.Println("[INFO] Creating node " + name + "::" + address)
-	err := client.CreateNode(
-		name,
-		address,
-	)
-	if err != nil {
-		return err
+	if (address == "") == (fqdn == nil) {
+		return fmt.Errorf("Node %s must have exactly one of address or fqdn", name)
+	}
+
+	node := &bigip.Node{
+		Name:    name,
+		Address: address,
+		FQDN:    fqdn,
 	}
 
+	if fqdn != nil {
+		log.Println("[INFO] Creating node " + name + "::" + fqdn.Name)
+	} else {
+		log.Println("[INFO] Creating node " + name + "::" + address)
+	}
 	d.SetId(name)
+	err := client.Transaction(func(tx *bigip.BigIP) error {
+		err := tx.AddNode(node)
+		if err != nil {
+			return err
+		}
+		return resourceBigipLtmNodeUpdate(d, tx)
+	})
+	if err != nil {
+		d.SetId("")
+		return err
+	}
 
 	return resourceBigipLtmNodeRead(d, meta)
 }
@@ -74,8 +203,28 @@ func resourceBigipLtmNodeRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	rateLimit, _ := strconv.Atoi(node.RateLimit)
+
+	state := "enabled"
+	if node.State == "user-down" {
+		state = "forced-offline"
+	} else if node.Session == "user-disabled" {
+		state = "disabled"
+	}
+
 	d.Set("address", node.Address)
 	d.Set("name", name)
+	d.Set("fqdn", flattenNodeFQDN(node.FQDN))
+	d.Set("description", node.Description)
+	d.Set("connection_limit", node.ConnectionLimit)
+	d.Set("rate_limit", rateLimit)
+	d.Set("ratio", node.Ratio)
+	d.Set("dynamic_ratio", node.DynamicRatio)
+	monitors, min := parseMonitorRule(node.Monitor)
+	monitors = configuredPaths(client.Partition, strings.Split(d.Get("monitor").(string), " and "), monitors)
+	d.Set("monitor", strings.Join(monitors, " and "))
+	d.Set("availability_requirement", min)
+	d.Set("state", state)
 
 	return nil
 }
@@ -102,11 +251,47 @@ func resourceBigipLtmNodeUpdate(d *schema.ResourceData, meta interface{}) error
 
 	name := d.Id()
 
-	vs := &bigip.Node{
-		Address: d.Get("address").(string),
+	rateLimit := "disabled"
+	if r := d.Get("rate_limit").(int); r > 0 {
+		rateLimit = strconv.Itoa(r)
 	}
 
-	return client.ModifyNode(name, vs)
+	monitors := strings.Split(d.Get("monitor").(string), " and ")
+	for i, m := range monitors {
+		monitors[i] = monitorPath(client.Partition, m)
+	}
+	monitor, err := makeMonitorRule(monitors, d.Get("availability_requirement").(int))
+	if err != nil {
+		return err
+	}
+
+	node := &bigip.Node{
+		Description:     d.Get("description").(string),
+		ConnectionLimit: d.Get("connection_limit").(int),
+		RateLimit:       rateLimit,
+		Ratio:           d.Get("ratio").(int),
+		DynamicRatio:    d.Get("dynamic_ratio").(int),
+		Monitor:         monitor,
+	}
+	if fqdn := expandNodeFQDN(d.Get("fqdn").([]interface{})); fqdn != nil {
+		// Only the query intervals of an FQDN node can be changed
+		node.FQDN = &bigip.NodeFQDN{
+			Interval:     fqdn.Interval,
+			DownInterval: fqdn.DownInterval,
+		}
+	}
+
+	err = client.ModifyNode(name, node)
+	if err != nil {
+		return err
+	}
+
+	status := map[string]string{
+		"enabled":        "enable",
+		"disabled":       "disable",
+		"forced-offline": "offline",
+	}
+	return client.NodeStatus(name, status[d.Get("state").(string)])
 }
 
 func resourceBigipLtmNodeDelete(d *schema.ResourceData, meta interface{}) error {
@@ -116,30 +301,83 @@ func resourceBigipLtmNodeDelete(d *schema.ResourceData, meta interface{}) error
 	log.Println("[INFO] Deleting node " + name)
 
 	err := client.DeleteNode(name)
-	regex := regexp.MustCompile("referenced by a member of pool '\\/\\w+/([\\w-_.]+)")
 	for err != nil {
-		log.Println("[INFO] Deleting %s from pools...", name)
-		parts := regex.FindStringSubmatch(err.Error())
-		if len(parts) > 1 {
-			poolName := parts[1]
-			members, e := client.PoolMembers(poolName)
+		// A node can't be deleted while pools still have members on it. Those
+		// members are removed, leaving the other members of the pool alone
+		parts := NODE_REFERENCE.FindStringSubmatch(err.Error())
+		if len(parts) < 2 {
+			break
+		}
+		pool := parts[1]
+		if partition, _ := parseF5Identifier(pool); partition == "" {
+			break
+		}
+		log.Printf("[INFO] Deleting %s from pool %s...", name, pool)
+		members, e := client.PoolMembers(pool)
+		if e != nil {
+			return e
+		}
+		deleted := 0
+		for _, member := range members {
+			partition, address, _, e := bigip.ParseDestination(member.FullPath)
+			if e != nil || fullPath(partition, address) != name {
+				continue
+			}
+			e = client.DeletePoolMember(pool, member.FullPath)
 			if e != nil {
 				return e
 			}
-			for _, member := range members {
-				e = client.DeletePoolMember(poolName, member.Name)
-				if e != nil {
-					return e
-				}
-			}
-			err = client.DeleteNode(name)
-		} else {
+			deleted++
+		}
+		if deleted == 0 {
 			break
 		}
+		err = client.DeleteNode(name)
 	}
 	return err
 }
 
 func resourceBigipLtmNodeImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
 	return []*schema.ResourceData{d}, nil
 }
+
+// Convert the fqdn block into client settings. Returns nil for an address node
+func expandNodeFQDN(l []interface{}) *bigip.NodeFQDN {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	f := l[0].(map[string]interface{})
+
+	autopopulate := "disabled"
+	if f["autopopulate"].(bool) {
+		autopopulate = "enabled"
+	}
+
+	return &bigip.NodeFQDN{
+		Name:          f["name"].(string),
+		AddressFamily: f["address_family"].(string),
+		AutoPopulate:  autopopulate,
+		Interval:      f["interval"].(string),
+		DownInterval:  f["down_interval"].(int),
+	}
+}
+
+// Convert FQDN settings into the fqdn block. BIG-IP reports FQDN settings for
+// every node, so only nodes with a name are FQDN nodes
+func flattenNodeFQDN(fqdn *bigip.NodeFQDN) []interface{} {
+	if fqdn == nil || fqdn.Name == "" {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"name":           fqdn.Name,
+			"address_family": fqdn.AddressFamily,
+			"autopopulate":   fqdn.AutoPopulate == "enabled",
+			"interval":       fqdn.Interval,
+			"down_interval":  fqdn.DownInterval,
+		},
+	}
+}
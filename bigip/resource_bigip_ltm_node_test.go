@@ -5,6 +5,7 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/scottdware/go-bigip"
+	"strings"
 	"testing"
 )
 
@@ -17,8 +18,82 @@ resource "bigip_ltm_node" "test-node" {
 }
 `
 
+var TEST_NODE_IN_POOL_RESOURCE = `
+resource "bigip_ltm_pool" "test-pool" {
+	name = "` + TEST_POOL_NAME + `"
+	monitors = ["/Common/http"]
+	nodes = ["test-node:80"]
+	depends_on = ["bigip_ltm_node.test-node"]
+}
+`
+
+var TEST_NODE_RESOURCE_UPDATED = `
+resource "bigip_ltm_node" "test-node" {
+	name = "` + TEST_NODE_NAME + `"
+	address = "10.10.10.10"
+	description = "test node"
+	connection_limit = 100
+	rate_limit = 50
+	ratio = 3
+	dynamic_ratio = 2
+	monitor = "/Common/icmp"
+	state = "disabled"
+}
+`
+
+var TEST_NODE_MIN_MONITORS_RESOURCE = `
+resource "bigip_ltm_node" "test-node" {
+	name = "` + TEST_NODE_NAME + `"
+	address = "10.10.10.10"
+	monitor = "/Common/icmp and /Common/gateway_icmp"
+	availability_requirement = 1
+}
+`
+
+var TEST_NODE_SHORT_MONITORS_RESOURCE = `
+provider "bigip" {
+	partition = "Common"
+}
+
+resource "bigip_ltm_node" "test-node" {
+	name = "` + TEST_NODE_NAME + `"
+	address = "10.10.10.10"
+	monitor = "icmp and /Common/gateway_icmp"
+}
+`
+
+var TEST_NODE_RESOURCE_OFFLINE = `
+resource "bigip_ltm_node" "test-node" {
+	name = "` + TEST_NODE_NAME + `"
+	address = "10.10.10.10"
+	state = "forced-offline"
+}
+`
+
+var TEST_NODE_FQDN_RESOURCE = `
+resource "bigip_ltm_node" "test-node" {
+	name = "` + TEST_NODE_NAME + `"
+	fqdn {
+		name = "www.example.com"
+		autopopulate = true
+	}
+}
+`
+
+var TEST_NODE_FQDN_RESOURCE_UPDATED = `
+resource "bigip_ltm_node" "test-node" {
+	name = "` + TEST_NODE_NAME + `"
+	fqdn {
+		name = "www.example.com"
+		autopopulate = true
+		interval = "ttl"
+		down_interval = 10
+	}
+}
+`
+
 func TestBigipLtmNode_create(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAcctPreCheck(t)
 		},
@@ -31,6 +106,150 @@ func TestBigipLtmNode_create(t *testing.T) {
 					testCheckNodeExists(TEST_NODE_NAME, true),
 					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "name", TEST_NODE_NAME),
 					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "address", "10.10.10.10"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "connection_limit", "0"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "rate_limit", "0"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "ratio", "1"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", "default"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "state", "enabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "fqdn.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipLtmNode_update(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckNodesDestroyed,
+			testCheckPoolsDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_NODE_RESOURCE + TEST_NODE_IN_POOL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckNodeExists(TEST_NODE_NAME, true),
+					testCheckPoolMember(TEST_POOL_NAME, "test-node:80"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_NODE_RESOURCE_UPDATED + TEST_NODE_IN_POOL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolMember(TEST_POOL_NAME, "test-node:80"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "description", "test node"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "connection_limit", "100"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "rate_limit", "50"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "ratio", "3"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "dynamic_ratio", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", "/Common/icmp"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "state", "disabled"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_NODE_RESOURCE_OFFLINE + TEST_NODE_IN_POOL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolMember(TEST_POOL_NAME, "test-node:80"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "description", ""),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "connection_limit", "0"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "state", "forced-offline"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_NODE_RESOURCE + TEST_NODE_IN_POOL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "state", "enabled"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_NODE_MIN_MONITORS_RESOURCE + TEST_NODE_IN_POOL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", "/Common/icmp and /Common/gateway_icmp"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "availability_requirement", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipLtmNode_fqdn(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckNodesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_NODE_FQDN_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckNodeExists(TEST_NODE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "address", "any6"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "fqdn.#", "1"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "fqdn.0.name", "www.example.com"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "fqdn.0.address_family", "ipv4"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "fqdn.0.autopopulate", "true"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "fqdn.0.interval", "3600"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_NODE_FQDN_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "fqdn.0.name", "www.example.com"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "fqdn.0.interval", "ttl"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "fqdn.0.down_interval", "10"),
+				),
+			},
+		},
+	})
+}
+
+var TEST_NODE_ROUTE_DOMAIN_RESOURCE = TEST_ROUTE_DOMAIN_RESOURCE + `
+resource "bigip_ltm_node" "test-node" {
+	name = "` + TEST_NODE_NAME + `"
+	address = "10.10.10.10%${bigip_net_route_domain.test-route-domain.route_domain_id}"
+}
+`
+
+func TestBigipLtmNode_routeDomain(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckNodesDestroyed,
+			testCheckRouteDomainsDestroyed,
+			testCheckVlansDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_NODE_ROUTE_DOMAIN_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckNodeExists(TEST_NODE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "address", "10.10.10.10%42"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipLtmNode_shortMonitors(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckNodesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_NODE_SHORT_MONITORS_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckNodeMonitor(TEST_NODE_NAME, "/Common/icmp and /Common/gateway_icmp"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", "icmp and /Common/gateway_icmp"),
 				),
 			},
 		},
@@ -38,7 +257,7 @@ func TestBigipLtmNode_create(t *testing.T) {
 }
 
 func TestBigipLtmNode_import(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAcctPreCheck(t)
 		},
@@ -58,6 +277,45 @@ func TestBigipLtmNode_import(t *testing.T) {
 	})
 }
 
+// Deleting a node that pool members are on removes those members, leaving the
+// other members of the pool, e.g. ones added by bigip_ltm_pool_attachment, alone.
+func TestBigipLtmNode_deleteFromPool(t *testing.T) {
+	mock := newMockBigip("admin", "admin")
+	defer mock.Close()
+	client := bigip.NewSession(mock.URL, "admin", "admin", nil)
+
+	pool := "/tenant-a/shared-pool"
+	for name, address := range map[string]string{"/tenant-a/node-a": "10.10.10.1", "/tenant-a/node-b": "10.10.10.2"} {
+		if err := client.CreateNode(name, address); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := client.CreatePool(pool); err != nil {
+		t.Fatal(err)
+	}
+	for _, member := range []string{"/tenant-a/node-a:80", "/tenant-a/node-b:80", "/tenant-a/node-a:443"} {
+		if err := client.AddPoolMember(pool, member); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d := resourceBigipLtmNode().Data(&terraform.InstanceState{ID: "/tenant-a/node-a"})
+	if err := resourceBigipLtmNodeDelete(d, client); err != nil {
+		t.Fatal(err)
+	}
+
+	if node, err := client.GetNode("/tenant-a/node-a"); err != nil || node != nil {
+		t.Errorf("Node /tenant-a/node-a not deleted: %v", err)
+	}
+	members, err := client.PoolMembers(pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 || members[0].FullPath != "/tenant-a/node-b:80" {
+		t.Errorf("Pool %s members are %v, expected only /tenant-a/node-b:80", pool, members)
+	}
+}
+
 //var TEST_NODE_IN_POOL_RESOURCE = `
 //resource "bigip_ltm_pool" "test-pool" {
 //	name = "` + TEST_POOL_NAME + `"
@@ -67,7 +325,7 @@ func TestBigipLtmNode_import(t *testing.T) {
 //}
 //`
 //func TestBigipLtmNode_removeNode(t *testing.T) {
-//	resource.Test(t, resource.TestCase{
+//	resource.UnitTest(t, resource.TestCase{
 //		PreCheck: func() {
 //			testAcctPreCheck(t)
 //		},
@@ -102,10 +360,28 @@ func testCheckNodeExists(name string, exists bool) resource.TestCheckFunc {
 			return err
 		}
 		if exists && node == nil {
-			return fmt.Errorf("Node ", name, " was not created.")
+			return fmt.Errorf("Node %s was not created.", name)
 		}
 		if !exists && node != nil {
-			return fmt.Errorf("Node ", name, " still exists.")
+			return fmt.Errorf("Node %s still exists.", name)
+		}
+		return nil
+	}
+}
+
+func testCheckNodeMonitor(name, monitor string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		node, err := client.GetNode(name)
+		if err != nil {
+			return err
+		}
+		if node == nil {
+			return fmt.Errorf("Node %s was not created.", name)
+		}
+		if strings.TrimSpace(node.Monitor) != monitor {
+			return fmt.Errorf("Node %s monitor is %s, expected %s", name, node.Monitor, monitor)
 		}
 		return nil
 	}
@@ -125,7 +401,7 @@ func testCheckNodesDestroyed(s *terraform.State) error {
 			return err
 		}
 		if node != nil {
-			return fmt.Errorf("Node ", name, " not destroyed.")
+			return fmt.Errorf("Node %s not destroyed.", name)
 		}
 	}
 	return nil
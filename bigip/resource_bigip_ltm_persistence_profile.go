@@ -0,0 +1,229 @@
+package bigip
+
+import (
+	"log"
+	"math"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+// A persistenceProfileType describes one of the ltm/persistence collections.
+// Every type shares the attributes in persistenceProfileSchema and adds its own
+// through schema, expand and flatten.
+type persistenceProfileType struct {
+	// Collection name, e.g. source-addr
+	uri string
+	// Description used in log messages, e.g. source address
+	description string
+	// Profile new profiles inherit from by default
+	parent  string
+	schema  map[string]*schema.Schema
+	expand  func(d *schema.ResourceData, client *bigip.BigIP, profile *bigip.PersistenceProfile)
+	flatten func(d *schema.ResourceData, client *bigip.BigIP, profile *bigip.PersistenceProfile)
+}
+
+func resourceBigipLtmPersistenceProfile(t *persistenceProfileType) *schema.Resource {
+	s := persistenceProfileSchema(t.parent)
+	for k, v := range t.schema {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		Create: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceBigipLtmPersistenceProfileCreate(t, d, meta)
+		},
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceBigipLtmPersistenceProfileRead(t, d, meta)
+		},
+		Update: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceBigipLtmPersistenceProfileUpdate(t, d, meta)
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceBigipLtmPersistenceProfileDelete(t, d, meta)
+		},
+		Exists: func(d *schema.ResourceData, meta interface{}) (bool, error) {
+			return resourceBigipLtmPersistenceProfileExists(t, d, meta)
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceBigipLtmPersistenceProfileImporter,
+		},
+
+		Schema: s,
+	}
+}
+
+func persistenceProfileSchema(parent string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Name of the persistence profile",
+			ForceNew:     true,
+			ValidateFunc: validateF5Name,
+		},
+
+		"parent": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      parent,
+			Description:  "Persistence profile to inherit settings from",
+			ValidateFunc: validateF5Name,
+		},
+
+		"match_across_pools": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  "enabled to persist to the same node across pools",
+			ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+		},
+
+		"match_across_services": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  "enabled to persist to the same node across virtual servers sharing an address",
+			ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+		},
+
+		"match_across_virtuals": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  "enabled to persist to the same node across all virtual servers",
+			ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+		},
+
+		"mirror": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  "enabled to mirror persistence records to the standby unit",
+			ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+		},
+
+		"override_connection_limit": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  "enabled to send persisted connections to a member even when it is at its connection limit",
+			ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+		},
+
+		"timeout": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  "Seconds a persistence record is kept after its last use, or indefinite",
+			ValidateFunc: validateKeywordOrIntRange([]string{"indefinite"}, 0, math.MaxInt32),
+		},
+	}
+}
+
+func resourceBigipLtmPersistenceProfileCreate(t *persistenceProfileType, d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := fullPath(client.Partition, d.Get("name").(string))
+
+	log.Println("[INFO] Creating " + t.description + " persistence profile " + name)
+	d.SetId(name)
+	err := client.Transaction(func(tx *bigip.BigIP) error {
+		err := tx.CreatePersistenceProfile(name, t.uri, fullPath(client.Partition, d.Get("parent").(string)))
+		if err != nil {
+			return err
+		}
+		return resourceBigipLtmPersistenceProfileUpdate(t, d, tx)
+	})
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceBigipLtmPersistenceProfileRead(t, d, meta)
+}
+
+func resourceBigipLtmPersistenceProfileRead(t *persistenceProfileType, d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	log.Println("[INFO] Reading " + t.description + " persistence profile " + name)
+
+	profile, err := client.GetPersistenceProfile(name, t.uri)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", name)
+	d.Set("parent", configuredPath(client.Partition, d.Get("parent").(string), profile.DefaultsFrom))
+	d.Set("match_across_pools", profile.MatchAcrossPools)
+	d.Set("match_across_services", profile.MatchAcrossServices)
+	d.Set("match_across_virtuals", profile.MatchAcrossVirtuals)
+	d.Set("mirror", profile.Mirror)
+	d.Set("override_connection_limit", profile.OverrideConnectionLimit)
+	d.Set("timeout", profile.Timeout)
+	if t.flatten != nil {
+		t.flatten(d, client, profile)
+	}
+
+	return nil
+}
+
+func resourceBigipLtmPersistenceProfileExists(t *persistenceProfileType, d *schema.ResourceData, meta interface{}) (bool, error) {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Checking " + t.description + " persistence profile " + name + " exists.")
+
+	profile, err := client.GetPersistenceProfile(name, t.uri)
+	if err != nil {
+		return false, err
+	}
+
+	if profile == nil {
+		d.SetId("")
+	}
+
+	return profile != nil, nil
+}
+
+func resourceBigipLtmPersistenceProfileUpdate(t *persistenceProfileType, d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	profile := &bigip.PersistenceProfile{
+		DefaultsFrom:            fullPath(client.Partition, d.Get("parent").(string)),
+		MatchAcrossPools:        d.Get("match_across_pools").(string),
+		MatchAcrossServices:     d.Get("match_across_services").(string),
+		MatchAcrossVirtuals:     d.Get("match_across_virtuals").(string),
+		Mirror:                  d.Get("mirror").(string),
+		OverrideConnectionLimit: d.Get("override_connection_limit").(string),
+		Timeout:                 d.Get("timeout").(string),
+	}
+	if t.expand != nil {
+		t.expand(d, client, profile)
+	}
+
+	return client.ModifyPersistenceProfile(name, t.uri, profile)
+}
+
+func resourceBigipLtmPersistenceProfileDelete(t *persistenceProfileType, d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting " + t.description + " persistence profile " + name)
+
+	return client.DeletePersistenceProfile(name, t.uri)
+}
+
+func resourceBigipLtmPersistenceProfileImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
+	return []*schema.ResourceData{d}, nil
+}
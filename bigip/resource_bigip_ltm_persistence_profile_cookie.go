@@ -0,0 +1,119 @@
+package bigip
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipLtmPersistenceProfileCookie() *schema.Resource {
+	return resourceBigipLtmPersistenceProfile(&persistenceProfileType{
+		uri:         "cookie",
+		description: "cookie",
+		parent:      "/Common/cookie",
+		expand:      expandPersistenceProfileCookie,
+		flatten:     flattenPersistenceProfileCookie,
+		schema: map[string]*schema.Schema{
+			"method": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "insert, rewrite, passive or hash",
+				ValidateFunc: validateStringValue([]string{"insert", "rewrite", "passive", "hash"}),
+			},
+
+			"cookie_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the cookie. BIG-IP generates one from the pool name when empty",
+			},
+
+			"expiration": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Cookie lifetime as d:h:m:s, or 0 for a session cookie",
+			},
+
+			"httponly": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "enabled to set the HttpOnly attribute on inserted cookies",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+
+			"secure": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "enabled to set the Secure attribute on inserted cookies",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+
+			"always_send": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "enabled to insert the cookie in every response rather than only the first",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+
+			"cookie_encryption": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "disabled, preferred or required",
+				ValidateFunc: validateStringValue([]string{"disabled", "preferred", "required"}),
+			},
+
+			"cookie_encryption_passphrase": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Passphrase used to encrypt the cookie. Not read back from the BIG-IP",
+			},
+
+			"hash_offset": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Offset into the cookie value hashed by the hash method",
+				ValidateFunc: validateIntRange(0, 65535),
+			},
+
+			"hash_length": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Number of bytes of the cookie value hashed by the hash method",
+				ValidateFunc: validateIntRange(0, 65535),
+			},
+		},
+	})
+}
+
+func expandPersistenceProfileCookie(d *schema.ResourceData, _ *bigip.BigIP, profile *bigip.PersistenceProfile) {
+	profile.Method = d.Get("method").(string)
+	profile.CookieName = d.Get("cookie_name").(string)
+	profile.Expiration = d.Get("expiration").(string)
+	profile.HTTPOnly = d.Get("httponly").(string)
+	profile.Secure = d.Get("secure").(string)
+	profile.AlwaysSend = d.Get("always_send").(string)
+	profile.CookieEncryption = d.Get("cookie_encryption").(string)
+	profile.CookieEncryptionPassphrase = d.Get("cookie_encryption_passphrase").(string)
+	profile.HashOffset = d.Get("hash_offset").(int)
+	profile.HashLength = d.Get("hash_length").(int)
+}
+
+func flattenPersistenceProfileCookie(d *schema.ResourceData, _ *bigip.BigIP, profile *bigip.PersistenceProfile) {
+	d.Set("method", profile.Method)
+	d.Set("cookie_name", profile.CookieName)
+	d.Set("expiration", profile.Expiration)
+	d.Set("httponly", profile.HTTPOnly)
+	d.Set("secure", profile.Secure)
+	d.Set("always_send", profile.AlwaysSend)
+	d.Set("cookie_encryption", profile.CookieEncryption)
+	d.Set("hash_offset", profile.HashOffset)
+	d.Set("hash_length", profile.HashLength)
+}
@@ -0,0 +1,84 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+var TEST_PERSISTENCE_COOKIE_NAME = fmt.Sprintf("/%s/test-cookie", TEST_PARTITION)
+
+var TEST_PERSISTENCE_COOKIE_RESOURCE = `
+resource "bigip_ltm_persistence_profile_cookie" "test-cookie" {
+	name = "` + TEST_PERSISTENCE_COOKIE_NAME + `"
+}
+`
+
+var TEST_PERSISTENCE_COOKIE_RESOURCE_UPDATED = `
+resource "bigip_ltm_persistence_profile_cookie" "test-cookie" {
+	name = "` + TEST_PERSISTENCE_COOKIE_NAME + `"
+	cookie_name = "app-session"
+	expiration = "1:0:0:0"
+	always_send = "enabled"
+	cookie_encryption = "required"
+	cookie_encryption_passphrase = "secret"
+	match_across_services = "enabled"
+	timeout = "3600"
+}
+`
+
+func TestBigipLtmPersistenceProfileCookie_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPersistenceProfilesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_PERSISTENCE_COOKIE_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPersistenceProfileExists(TEST_PERSISTENCE_COOKIE_NAME, "cookie", true),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_cookie.test-cookie", "name", TEST_PERSISTENCE_COOKIE_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_cookie.test-cookie", "parent", "/Common/cookie"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_cookie.test-cookie", "method", "insert"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_cookie.test-cookie", "match_across_services", "disabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_cookie.test-cookie", "timeout", "180"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_PERSISTENCE_COOKIE_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPersistenceProfileExists(TEST_PERSISTENCE_COOKIE_NAME, "cookie", true),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_cookie.test-cookie", "cookie_name", "app-session"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_cookie.test-cookie", "expiration", "1:0:0:0"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_cookie.test-cookie", "always_send", "enabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_cookie.test-cookie", "cookie_encryption", "required"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_cookie.test-cookie", "match_across_services", "enabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_cookie.test-cookie", "timeout", "3600"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipLtmPersistenceProfileCookie_import(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPersistenceProfilesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_PERSISTENCE_COOKIE_RESOURCE,
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_ltm_persistence_profile_cookie.test-cookie",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
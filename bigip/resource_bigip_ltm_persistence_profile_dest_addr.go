@@ -0,0 +1,42 @@
+package bigip
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipLtmPersistenceProfileDestAddr() *schema.Resource {
+	return resourceBigipLtmPersistenceProfile(&persistenceProfileType{
+		uri:         "dest-addr",
+		description: "destination address",
+		parent:      "/Common/dest_addr",
+		expand:      expandPersistenceProfileDestAddr,
+		flatten:     flattenPersistenceProfileDestAddr,
+		schema: map[string]*schema.Schema{
+			"hash_algorithm": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "default (index of the persistence table) or carp (consistent hashing)",
+				ValidateFunc: validateStringValue([]string{"default", "carp"}),
+			},
+
+			"mask": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Mask applied to the destination address so destinations in the same network persist together, e.g. 255.255.255.0, or none",
+			},
+		},
+	})
+}
+
+func expandPersistenceProfileDestAddr(d *schema.ResourceData, _ *bigip.BigIP, profile *bigip.PersistenceProfile) {
+	profile.HashAlgorithm = d.Get("hash_algorithm").(string)
+	profile.Mask = d.Get("mask").(string)
+}
+
+func flattenPersistenceProfileDestAddr(d *schema.ResourceData, _ *bigip.BigIP, profile *bigip.PersistenceProfile) {
+	d.Set("hash_algorithm", profile.HashAlgorithm)
+	d.Set("mask", profile.Mask)
+}
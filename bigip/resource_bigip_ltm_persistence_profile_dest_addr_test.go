@@ -0,0 +1,44 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+var TEST_PERSISTENCE_DEST_ADDR_NAME = fmt.Sprintf("/%s/test-dest-addr", TEST_PARTITION)
+
+var TEST_PERSISTENCE_DEST_ADDR_RESOURCE = `
+resource "bigip_ltm_persistence_profile_dest_addr" "test-dest-addr" {
+	name = "` + TEST_PERSISTENCE_DEST_ADDR_NAME + `"
+	mask = "255.255.0.0"
+	timeout = "indefinite"
+}
+`
+
+func TestBigipLtmPersistenceProfileDestAddr_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPersistenceProfilesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_PERSISTENCE_DEST_ADDR_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPersistenceProfileExists(TEST_PERSISTENCE_DEST_ADDR_NAME, "dest-addr", true),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_dest_addr.test-dest-addr", "parent", "/Common/dest_addr"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_dest_addr.test-dest-addr", "mask", "255.255.0.0"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_dest_addr.test-dest-addr", "timeout", "indefinite"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_ltm_persistence_profile_dest_addr.test-dest-addr",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,52 @@
+package bigip
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipLtmPersistenceProfileSourceAddr() *schema.Resource {
+	return resourceBigipLtmPersistenceProfile(&persistenceProfileType{
+		uri:         "source-addr",
+		description: "source address",
+		parent:      "/Common/source_addr",
+		expand:      expandPersistenceProfileSourceAddr,
+		flatten:     flattenPersistenceProfileSourceAddr,
+		schema: map[string]*schema.Schema{
+			"hash_algorithm": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "default (index of the persistence table) or carp (consistent hashing)",
+				ValidateFunc: validateStringValue([]string{"default", "carp"}),
+			},
+
+			"mask": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Mask applied to the source address so clients in the same network persist together, e.g. 255.255.255.0, or none",
+			},
+
+			"map_proxies": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "enabled to persist all known AOL proxy addresses together",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+		},
+	})
+}
+
+func expandPersistenceProfileSourceAddr(d *schema.ResourceData, _ *bigip.BigIP, profile *bigip.PersistenceProfile) {
+	profile.HashAlgorithm = d.Get("hash_algorithm").(string)
+	profile.Mask = d.Get("mask").(string)
+	profile.MapProxies = d.Get("map_proxies").(string)
+}
+
+func flattenPersistenceProfileSourceAddr(d *schema.ResourceData, _ *bigip.BigIP, profile *bigip.PersistenceProfile) {
+	d.Set("hash_algorithm", profile.HashAlgorithm)
+	d.Set("mask", profile.Mask)
+	d.Set("map_proxies", profile.MapProxies)
+}
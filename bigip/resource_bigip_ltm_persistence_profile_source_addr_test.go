@@ -0,0 +1,57 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+var TEST_PERSISTENCE_SOURCE_ADDR_NAME = fmt.Sprintf("/%s/test-source-addr", TEST_PARTITION)
+
+var TEST_PERSISTENCE_SOURCE_ADDR_RESOURCE = `
+resource "bigip_ltm_persistence_profile_source_addr" "test-source-addr" {
+	name = "` + TEST_PERSISTENCE_SOURCE_ADDR_NAME + `"
+}
+`
+
+var TEST_PERSISTENCE_SOURCE_ADDR_RESOURCE_UPDATED = `
+resource "bigip_ltm_persistence_profile_source_addr" "test-source-addr" {
+	name = "` + TEST_PERSISTENCE_SOURCE_ADDR_NAME + `"
+	hash_algorithm = "carp"
+	mask = "255.255.255.0"
+	map_proxies = "disabled"
+	mirror = "enabled"
+}
+`
+
+func TestBigipLtmPersistenceProfileSourceAddr_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPersistenceProfilesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_PERSISTENCE_SOURCE_ADDR_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPersistenceProfileExists(TEST_PERSISTENCE_SOURCE_ADDR_NAME, "source-addr", true),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_source_addr.test-source-addr", "parent", "/Common/source_addr"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_source_addr.test-source-addr", "hash_algorithm", "default"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_source_addr.test-source-addr", "mask", "none"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_PERSISTENCE_SOURCE_ADDR_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPersistenceProfileExists(TEST_PERSISTENCE_SOURCE_ADDR_NAME, "source-addr", true),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_source_addr.test-source-addr", "hash_algorithm", "carp"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_source_addr.test-source-addr", "mask", "255.255.255.0"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_source_addr.test-source-addr", "map_proxies", "disabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_source_addr.test-source-addr", "mirror", "enabled"),
+				),
+			},
+		},
+	})
+}
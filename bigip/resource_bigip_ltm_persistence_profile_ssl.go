@@ -0,0 +1,14 @@
+package bigip
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// SSL persistence keys on the SSL session ID and has no attributes of its own
+func resourceBigipLtmPersistenceProfileSSL() *schema.Resource {
+	return resourceBigipLtmPersistenceProfile(&persistenceProfileType{
+		uri:         "ssl",
+		description: "SSL",
+		parent:      "/Common/ssl",
+	})
+}
@@ -0,0 +1,38 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+var TEST_PERSISTENCE_SSL_NAME = fmt.Sprintf("/%s/test-ssl-persistence", TEST_PARTITION)
+
+var TEST_PERSISTENCE_SSL_RESOURCE = `
+resource "bigip_ltm_persistence_profile_ssl" "test-ssl" {
+	name = "` + TEST_PERSISTENCE_SSL_NAME + `"
+	match_across_pools = "enabled"
+}
+`
+
+func TestBigipLtmPersistenceProfileSSL_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPersistenceProfilesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_PERSISTENCE_SSL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPersistenceProfileExists(TEST_PERSISTENCE_SSL_NAME, "ssl", true),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_ssl.test-ssl", "parent", "/Common/ssl"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_ssl.test-ssl", "match_across_pools", "enabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_ssl.test-ssl", "timeout", "300"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,91 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/scottdware/go-bigip"
+)
+
+// Persistence profile collection for each resource type
+var persistenceProfileResourceTypes = map[string]string{
+	"bigip_ltm_persistence_profile_cookie":      "cookie",
+	"bigip_ltm_persistence_profile_source_addr": "source-addr",
+	"bigip_ltm_persistence_profile_dest_addr":   "dest-addr",
+	"bigip_ltm_persistence_profile_ssl":         "ssl",
+	"bigip_ltm_persistence_profile_universal":   "universal",
+}
+
+var TEST_VS_PERSISTENCE_RESOURCE = TEST_PERSISTENCE_COOKIE_RESOURCE + TEST_PERSISTENCE_SOURCE_ADDR_RESOURCE + `
+resource "bigip_ltm_virtual_server" "test-vs" {
+	name = "` + TEST_VS_NAME + `"
+	destination = "10.255.255.254"
+	port = 9999
+	persistence_profiles = ["${bigip_ltm_persistence_profile_cookie.test-cookie.name}"]
+	fallback_persistence_profile = "${bigip_ltm_persistence_profile_source_addr.test-source-addr.name}"
+}
+`
+
+func TestBigipLtmPersistenceProfile_virtualServer(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckVSsDestroyed,
+			testCheckPersistenceProfilesDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_VS_PERSISTENCE_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVSExists(TEST_VS_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "persistence_profiles.0", TEST_PERSISTENCE_COOKIE_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "fallback_persistence_profile", TEST_PERSISTENCE_SOURCE_ADDR_NAME),
+				),
+			},
+		},
+	})
+}
+
+func testCheckPersistenceProfileExists(name, persistenceType string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		profile, err := client.GetPersistenceProfile(name, persistenceType)
+		if err != nil {
+			return err
+		}
+		if exists && profile == nil {
+			return fmt.Errorf("%s persistence profile %s does not exist.", persistenceType, name)
+		}
+		if !exists && profile != nil {
+			return fmt.Errorf("%s persistence profile %s exists.", persistenceType, name)
+		}
+		return nil
+	}
+}
+
+func testCheckPersistenceProfilesDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		persistenceType, ok := persistenceProfileResourceTypes[rs.Type]
+		if !ok {
+			continue
+		}
+
+		name := rs.Primary.ID
+		profile, err := client.GetPersistenceProfile(name, persistenceType)
+		if err != nil {
+			return err
+		}
+		if profile != nil {
+			return fmt.Errorf("%s persistence profile %s not destroyed.", persistenceType, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package bigip
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipLtmPersistenceProfileUniversal() *schema.Resource {
+	return resourceBigipLtmPersistenceProfile(&persistenceProfileType{
+		uri:         "universal",
+		description: "universal",
+		parent:      "/Common/universal",
+		expand:      expandPersistenceProfileUniversal,
+		flatten:     flattenPersistenceProfileUniversal,
+		schema: map[string]*schema.Schema{
+			"irule": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "iRule that picks the persistence key with the persist uie command, e.g. ${bigip_ltm_irule.rule.name}",
+				ValidateFunc: validateF5Name,
+			},
+		},
+	})
+}
+
+func expandPersistenceProfileUniversal(d *schema.ResourceData, client *bigip.BigIP, profile *bigip.PersistenceProfile) {
+	profile.Rule = fullPath(client.Partition, d.Get("irule").(string))
+	if profile.Rule == "" {
+		profile.Rule = "none"
+	}
+}
+
+func flattenPersistenceProfileUniversal(d *schema.ResourceData, client *bigip.BigIP, profile *bigip.PersistenceProfile) {
+	if profile.Rule == "none" {
+		profile.Rule = ""
+	}
+	d.Set("irule", configuredPath(client.Partition, d.Get("irule").(string), profile.Rule))
+}
@@ -0,0 +1,56 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+var TEST_PERSISTENCE_UNIVERSAL_NAME = fmt.Sprintf("/%s/test-universal", TEST_PARTITION)
+
+var TEST_PERSISTENCE_UNIVERSAL_RESOURCE = TEST_IRULE_RESOURCE + `
+resource "bigip_ltm_persistence_profile_universal" "test-universal" {
+	name = "` + TEST_PERSISTENCE_UNIVERSAL_NAME + `"
+	irule = "${bigip_ltm_irule.test-rule.name}"
+	timeout = "600"
+}
+`
+
+var TEST_PERSISTENCE_UNIVERSAL_RESOURCE_NO_RULE = TEST_IRULE_RESOURCE + `
+resource "bigip_ltm_persistence_profile_universal" "test-universal" {
+	name = "` + TEST_PERSISTENCE_UNIVERSAL_NAME + `"
+	timeout = "600"
+}
+`
+
+func TestBigipLtmPersistenceProfileUniversal_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckPersistenceProfilesDestroyed,
+			testCheckIRulesDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_PERSISTENCE_UNIVERSAL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPersistenceProfileExists(TEST_PERSISTENCE_UNIVERSAL_NAME, "universal", true),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_universal.test-universal", "parent", "/Common/universal"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_universal.test-universal", "irule", TEST_IRULE_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_universal.test-universal", "timeout", "600"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_PERSISTENCE_UNIVERSAL_RESOURCE_NO_RULE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPersistenceProfileExists(TEST_PERSISTENCE_UNIVERSAL_NAME, "universal", true),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_universal.test-universal", "irule", ""),
+				),
+			},
+		},
+	})
+}
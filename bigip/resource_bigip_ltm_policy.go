@@ -1054,10 +1054,10 @@ func resourceBigipLtmPolicy() *schema.Resource {
 
 func resourceBigipLtmPolicyCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*bigip.BigIP)
-	name := d.Get("name").(string)
+	name := fullPath(client.Partition, d.Get("name").(string))
 	log.Println("[INFO] Creating Policy " + name)
 
-	p := dataToPolicy(name, d)
+	p := dataToPolicy(name, d, client)
 
 	d.SetId(name)
 	err := client.CreatePolicy(&p)
@@ -1079,7 +1079,7 @@ func resourceBigipLtmPolicyRead(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	return policyToData(p, d)
+	return policyToData(p, d, client)
 }
 
 func resourceBigipLtmPolicyExists(d *schema.ResourceData, meta interface{}) (bool, error) {
@@ -1100,7 +1100,7 @@ func resourceBigipLtmPolicyUpdate(d *schema.ResourceData, meta interface{}) erro
 	client := meta.(*bigip.BigIP)
 	name := d.Id()
 	log.Println("[INFO] Updating Policy " + name)
-	p := dataToPolicy(name, d)
+	p := dataToPolicy(name, d, client)
 	return client.UpdatePolicy(name, &p)
 }
 
@@ -1110,10 +1110,10 @@ func resourceBigipLtmPolicyDelete(d *schema.ResourceData, meta interface{}) erro
 	return client.DeletePolicy(name)
 }
 
-func dataToPolicy(name string, d *schema.ResourceData) bigip.Policy {
+func dataToPolicy(name string, d *schema.ResourceData, client *bigip.BigIP) bigip.Policy {
 	var p bigip.Policy
 	p.Name = name
-	p.Strategy = d.Get("strategy").(string)
+	p.Strategy = fullPath(client.Partition, d.Get("strategy").(string))
 	p.Controls = setToStringSlice(d.Get("controls").(*schema.Set))
 	p.Requires = setToStringSlice(d.Get("requires").(*schema.Set))
 
@@ -1122,7 +1122,7 @@ func dataToPolicy(name string, d *schema.ResourceData) bigip.Policy {
 	for i := 0; i < ruleCount; i++ {
 		var r bigip.PolicyRule
 		prefix := fmt.Sprintf("rule.%d", i)
-		r.Name = d.Get(prefix + ".name").(string)
+		r.Name = fullPath(client.Partition, d.Get(prefix+".name").(string))
 
 		actionCount := d.Get(prefix + ".action.#").(int)
 		r.Actions = make([]bigip.PolicyRuleAction, actionCount, actionCount)
@@ -1145,14 +1145,16 @@ func dataToPolicy(name string, d *schema.ResourceData) bigip.Policy {
 	return p
 }
 
-func policyToData(p *bigip.Policy, d *schema.ResourceData) error {
-	d.Set("strategy", p.Strategy)
+func policyToData(p *bigip.Policy, d *schema.ResourceData, client *bigip.BigIP) error {
+	d.Set("name", d.Id())
+	d.Set("strategy", configuredPath(client.Partition, d.Get("strategy").(string), p.Strategy))
 	d.Set("controls", makeStringSet(&p.Controls))
 	d.Set("requires", makeStringSet(&p.Requires))
 
 	for i, r := range p.Rules {
 		rule := fmt.Sprintf("rule.%d", i)
-		d.Set(fmt.Sprintf("%s.name", rule), r.FullPath)
+		name := fmt.Sprintf("%s.name", rule)
+		d.Set(name, configuredPath(client.Partition, d.Get(name).(string), r.FullPath))
 
 		for x, a := range r.Actions {
 			action := fmt.Sprintf("%s.action.%d", rule, x)
@@ -1181,5 +1183,7 @@ func interfaceToResourceData(obj interface{}, d *schema.ResourceData, prefix str
 }
 
 func resourceBigipLtmPolicyImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
 	return []*schema.ResourceData{d}, nil
 }
@@ -47,7 +47,7 @@ resource "bigip_ltm_policy" "test-policy" {
 `
 
 func TestBigipLtmPolicy_create(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAcctPreCheck(t)
 		},
@@ -84,7 +84,7 @@ func TestBigipLtmPolicy_create(t *testing.T) {
 }
 
 func TestBigipLtmPolicy_import(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAcctPreCheck(t)
 		},
@@ -2,8 +2,8 @@ package bigip
 
 import (
 	"log"
+	"math"
 	"regexp"
-	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/scottdware/go-bigip"
@@ -11,6 +11,28 @@ import (
 
 var NODE_VALIDATION = regexp.MustCompile(":\\d{2,5}$")
 
+var LOAD_BALANCING_MODES = []string{
+	"round-robin",
+	"ratio-member",
+	"ratio-node",
+	"ratio-session",
+	"ratio-least-connections-member",
+	"ratio-least-connections-node",
+	"dynamic-ratio-member",
+	"dynamic-ratio-node",
+	"least-connections-member",
+	"least-connections-node",
+	"weighted-least-connections-member",
+	"weighted-least-connections-node",
+	"least-sessions",
+	"fastest-node",
+	"fastest-app-response",
+	"observed-member",
+	"observed-node",
+	"predictive-member",
+	"predictive-node",
+}
+
 func resourceBigipLtmPool() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceBigipLtmPoolCreate,
@@ -35,7 +57,7 @@ func resourceBigipLtmPool() *schema.Resource {
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Set:         schema.HashString,
 				Optional:    true,
-				Description: "Nodes to add to the pool. Format node_name:port. e.g. node01:443",
+				Description: "Nodes to add to the pool. Format node_name:port. e.g. node01:443. Leave unset when members are managed with bigip_ltm_pool_attachment",
 			},
 
 			"monitors": &schema.Schema{
@@ -46,6 +68,14 @@ func resourceBigipLtmPool() *schema.Resource {
 				Description: "Assign monitors to a pool.",
 			},
 
+			"availability_requirement": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Number of monitors that must pass for a member to be up. 0 means all of them",
+				ValidateFunc: validateIntRange(0, 65535),
+			},
+
 			"allow_nat": &schema.Schema{
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -61,10 +91,134 @@ func resourceBigipLtmPool() *schema.Resource {
 			},
 
 			"load_balancing_mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "round-robin",
+				Description:  "Load balancing method, e.g. round-robin, least-connections-member or ratio-member",
+				ValidateFunc: validateStringValue(LOAD_BALANCING_MODES),
+			},
+
+			"description": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     "round-robin",
-				Description: "Possible values: round-robin, ...",
+				Description: "User defined description",
+			},
+
+			"min_active_members": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Priority group activation: send traffic to the next lower priority group when fewer members than this are available. 0 disables priority groups",
+				ValidateFunc: validateIntRange(0, 65535),
+			},
+
+			"min_up_members": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Minimum number of members that must be up before min_up_members_action is taken",
+				ValidateFunc: validateIntRange(0, 65535),
+			},
+
+			"min_up_members_action": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "failover",
+				Description:  "Action taken when fewer than min_up_members are up: failover, reboot or restart-all",
+				ValidateFunc: validateStringValue([]string{"failover", "reboot", "restart-all"}),
+			},
+
+			"min_up_members_checking": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Take min_up_members_action when fewer than min_up_members are up",
+			},
+
+			"slow_ramp_time": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				Description:  "Seconds over which a newly available member ramps up to its full share of traffic",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+
+			"service_down_action": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "none",
+				Description:  "Action taken on connections to a member that goes down: none, reset, drop or reselect",
+				ValidateFunc: validateStringValue([]string{"none", "reset", "drop", "reselect"}),
+			},
+
+			"reselect_tries": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Number of times to try another member when a connection to the selected member fails",
+				ValidateFunc: validateIntRange(0, 65535),
+			},
+
+			"queue_on_connection_limit": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Queue connections when every member has reached its connection limit",
+			},
+
+			"queue_depth_limit": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Maximum number of queued connections. 0 means unlimited",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+
+			"queue_time_limit": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Maximum milliseconds a connection stays queued. 0 means unlimited",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+
+			"ip_tos_to_client": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "pass-through",
+				Description:  "IP ToS set on packets to the client: pass-through, mimic or 0-255",
+				ValidateFunc: validateKeywordOrIntRange([]string{"pass-through", "mimic"}, 0, 255),
+			},
+
+			"ip_tos_to_server": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "pass-through",
+				Description:  "IP ToS set on packets to the server: pass-through, mimic or 0-255",
+				ValidateFunc: validateKeywordOrIntRange([]string{"pass-through", "mimic"}, 0, 255),
+			},
+
+			"link_qos_to_client": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "pass-through",
+				Description:  "Link QoS set on packets to the client: pass-through or 0-7",
+				ValidateFunc: validateKeywordOrIntRange([]string{"pass-through"}, 0, 7),
+			},
+
+			"link_qos_to_server": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "pass-through",
+				Description:  "Link QoS set on packets to the server: pass-through or 0-7",
+				ValidateFunc: validateKeywordOrIntRange([]string{"pass-through"}, 0, 7),
+			},
+
+			"ignore_persisted_weight": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Ignore persisted connections when ratio or least connections modes weigh members",
 			},
 		},
 	}
@@ -73,18 +227,24 @@ func resourceBigipLtmPool() *schema.Resource {
 func resourceBigipLtmPoolCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*bigip.BigIP)
 
-	name := d.Get("name").(string)
+	name := fullPath(client.Partition, d.Get("name").(string))
 
 	log.Println("[INFO] Creating pool " + name)
-	err := client.CreatePool(name)
-	if err != nil {
-		return err
-	}
 	d.SetId(name)
-
-	err = resourceBigipLtmPoolUpdate(d, meta)
+	err := client.Transaction(func(tx *bigip.BigIP) error {
+		err := tx.CreatePool(name)
+		if err != nil {
+			return err
+		}
+		err = modifyPool(tx, d)
+		if err != nil {
+			return err
+		}
+		// the pool is new, so every node is added
+		return updatePoolMembers(tx, name, schema.NewSet(schema.HashString, nil), d.Get("nodes").(*schema.Set))
+	})
 	if err != nil {
-		client.DeletePool(name)
+		d.SetId("")
 		return err
 	}
 
@@ -102,25 +262,41 @@ func resourceBigipLtmPoolRead(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return err
 	}
-	nodes, err := client.PoolMembers(name)
-	if err != nil {
-		return err
-	}
 
-	nodeNames := make([]string, 0, len(nodes))
-
-	for _, node := range nodes {
-		nodeNames = append(nodeNames, node.Name)
+	//only the members nodes manages are read back, so members added by
+	//bigip_ltm_pool_attachment don't show up as changes to the pool
+	if nodes, ok := d.GetOk("nodes"); ok {
+		err = readPoolNodes(client, d, nodes.(*schema.Set))
+		if err != nil {
+			return err
+		}
 	}
 
 	d.Set("allow_nat", pool.AllowNAT)
 	d.Set("allow_snat", pool.AllowSNAT)
 	d.Set("load_balancing_mode", pool.LoadBalancingMode)
-	d.Set("nodes", makeStringSet(&nodeNames))
 	d.Set("name", name)
-
-	monitors := strings.Split(strings.TrimSpace(pool.Monitor), " and ")
+	d.Set("description", pool.Description)
+	d.Set("min_active_members", pool.MinActiveMembers)
+	d.Set("min_up_members", pool.MinUpMembers)
+	d.Set("min_up_members_action", pool.MinUpMembersAction)
+	d.Set("min_up_members_checking", pool.MinUpMembersChecking == "enabled")
+	d.Set("slow_ramp_time", pool.SlowRampTime)
+	d.Set("service_down_action", pool.ServiceDownAction)
+	d.Set("reselect_tries", pool.ReselectTries)
+	d.Set("queue_on_connection_limit", pool.QueueOnConnectionLimit == "enabled")
+	d.Set("queue_depth_limit", pool.QueueDepthLimit)
+	d.Set("queue_time_limit", pool.QueueTimeLimit)
+	d.Set("ip_tos_to_client", pool.IPTOSToClient)
+	d.Set("ip_tos_to_server", pool.IPTOSToServer)
+	d.Set("link_qos_to_client", pool.LinkQoSToClient)
+	d.Set("link_qos_to_server", pool.LinkQoSToServer)
+	d.Set("ignore_persisted_weight", pool.IgnorePersistedWeight)
+
+	monitors, min := parseMonitorRule(pool.Monitor)
+	monitors = configuredPaths(client.Partition, setToStringSlice(d.Get("monitors").(*schema.Set)), monitors)
 	d.Set("monitors", makeStringSet(&monitors))
+	d.Set("availability_requirement", min)
 
 	return nil
 }
@@ -148,53 +324,115 @@ func resourceBigipLtmPoolUpdate(d *schema.ResourceData, meta interface{}) error
 
 	name := d.Id()
 
-	//monitors
-	var monitors []string
-	if m, ok := d.GetOk("monitors"); ok {
-		for _, monitor := range m.(*schema.Set).List() {
-			monitors = append(monitors, monitor.(string))
-		}
+	err := modifyPool(client, d)
+	if err != nil {
+		return err
 	}
 
-	pool := &bigip.Pool{
-		AllowNAT:          d.Get("allow_nat").(bool),
-		AllowSNAT:         d.Get("allow_snat").(bool),
-		LoadBalancingMode: d.Get("load_balancing_mode").(string),
-		Monitor:           strings.Join(monitors, " and "),
+	//members, only those added or removed from nodes change so bigip_ltm_pool_attachment members survive
+	if !d.HasChange("nodes") {
+		return nil
 	}
+	o, n := d.GetChange("nodes")
+	return updatePoolMembers(client, name, o.(*schema.Set), n.(*schema.Set))
+}
 
-	err := client.ModifyPool(name, pool)
+// readPoolNodes sets nodes to the members of the pool that are in managed, in the
+// form they are given there, or to every member if managed is nil.
+func readPoolNodes(client *bigip.BigIP, d *schema.ResourceData, managed *schema.Set) error {
+	nodes, err := client.PoolMembers(d.Id())
 	if err != nil {
 		return err
 	}
 
-	//members
-	nodes, err := client.PoolMembers(name)
-	if err != nil {
-		return err
+	var names map[string]string
+	if managed != nil {
+		names = make(map[string]string, managed.Len())
+		for _, name := range setToStringSlice(managed) {
+			names[name] = name
+			names[fullPath(client.Partition, name)] = name
+		}
 	}
 
 	nodeNames := make([]string, 0, len(nodes))
 
 	for _, node := range nodes {
-		nodeNames = append(nodeNames, node.Name)
+		if names == nil {
+			nodeNames = append(nodeNames, node.Name)
+		} else if name, ok := names[node.Name]; ok {
+			nodeNames = append(nodeNames, name)
+		} else if name, ok := names[node.FullPath]; ok {
+			nodeNames = append(nodeNames, name)
+		}
 	}
 
-	existing := makeStringSet(&nodeNames)
-	incoming := d.Get("nodes").(*schema.Set)
-	delete := existing.Difference(incoming)
-	add := incoming.Difference(existing)
-	if delete.Len() > 0 {
-		for _, d := range delete.List() {
-			client.DeletePoolMember(name, d.(string))
+	d.Set("nodes", makeStringSet(&nodeNames))
+	return nil
+}
+
+func modifyPool(client *bigip.BigIP, d *schema.ResourceData) error {
+	//monitors
+	var monitors []string
+	if m, ok := d.GetOk("monitors"); ok {
+		for _, monitor := range m.(*schema.Set).List() {
+			monitors = append(monitors, fullPath(client.Partition, monitor.(string)))
 		}
 	}
-	if add.Len() > 0 {
-		for _, d := range add.List() {
-			client.AddPoolMember(name, d.(string))
-		}
+	monitor, err := makeMonitorRule(monitors, d.Get("availability_requirement").(int))
+	if err != nil {
+		return err
+	}
+
+	minUpMembersChecking := "disabled"
+	if d.Get("min_up_members_checking").(bool) {
+		minUpMembersChecking = "enabled"
+	}
+	queueOnConnectionLimit := "disabled"
+	if d.Get("queue_on_connection_limit").(bool) {
+		queueOnConnectionLimit = "enabled"
+	}
+
+	pool := &bigip.Pool{
+		AllowNAT:               d.Get("allow_nat").(bool),
+		AllowSNAT:              d.Get("allow_snat").(bool),
+		LoadBalancingMode:      d.Get("load_balancing_mode").(string),
+		Monitor:                monitor,
+		Description:            d.Get("description").(string),
+		MinActiveMembers:       d.Get("min_active_members").(int),
+		MinUpMembers:           d.Get("min_up_members").(int),
+		MinUpMembersAction:     d.Get("min_up_members_action").(string),
+		MinUpMembersChecking:   minUpMembersChecking,
+		SlowRampTime:           d.Get("slow_ramp_time").(int),
+		ServiceDownAction:      d.Get("service_down_action").(string),
+		ReselectTries:          d.Get("reselect_tries").(int),
+		QueueOnConnectionLimit: queueOnConnectionLimit,
+		QueueDepthLimit:        d.Get("queue_depth_limit").(int),
+		QueueTimeLimit:         d.Get("queue_time_limit").(int),
+		IPTOSToClient:          d.Get("ip_tos_to_client").(string),
+		IPTOSToServer:          d.Get("ip_tos_to_server").(string),
+		LinkQoSToClient:        d.Get("link_qos_to_client").(string),
+		LinkQoSToServer:        d.Get("link_qos_to_server").(string),
+		IgnorePersistedWeight:  d.Get("ignore_persisted_weight").(bool),
 	}
 
+	return client.ModifyPool(d.Id(), pool)
+}
+
+// updatePoolMembers removes the members of pool name that aren't in incoming and
+// adds those that aren't in existing.
+func updatePoolMembers(client *bigip.BigIP, name string, existing, incoming *schema.Set) error {
+	for _, d := range existing.Difference(incoming).List() {
+		err := client.DeletePoolMember(name, d.(string))
+		if err != nil {
+			return err
+		}
+	}
+	for _, d := range incoming.Difference(existing).List() {
+		err := client.AddPoolMember(name, d.(string))
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -208,5 +446,12 @@ func resourceBigipLtmPoolDelete(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceBigIpLtmPoolImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
+	//imported pools manage the members they have with nodes
+	err := readPoolNodes(client, d, nil)
+	if err != nil {
+		return nil, err
+	}
 	return []*schema.ResourceData{d}, nil
 }
@@ -0,0 +1,232 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipLtmPoolAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipLtmPoolAttachmentCreate,
+		Read:   resourceBigipLtmPoolAttachmentRead,
+		Update: resourceBigipLtmPoolAttachmentUpdate,
+		Delete: resourceBigipLtmPoolAttachmentDelete,
+		Exists: resourceBigipLtmPoolAttachmentExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceBigipLtmPoolAttachmentImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"pool": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the pool to add the member to",
+				ValidateFunc: validateF5Name,
+			},
+
+			"node": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Pool member in the form /Partition/node:port or node:port, e.g. /Common/web1:80",
+				ValidateFunc: validatePoolMemberName,
+			},
+
+			"ratio": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "Ratio weight used by ratio load balancing modes",
+				ValidateFunc: validateIntRange(1, 65535),
+			},
+
+			"dynamic_ratio": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "Ratio weight used by dynamic ratio load balancing modes",
+				ValidateFunc: validateIntRange(1, 65535),
+			},
+
+			"priority_group": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Priority group of the member. Higher groups receive traffic first when priority group activation is enabled on the pool",
+				ValidateFunc: validateIntRange(0, 65535),
+			},
+
+			"connection_limit": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Maximum concurrent connections to the member. 0 means unlimited",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+
+			"monitor": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+				Description: "Health monitor for the member: default (inherit from the pool), none or a monitor name, e.g. /Common/tcp",
+			},
+
+			"state": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "enabled",
+				Description:  "enabled, disabled (only persistent and active connections) or forced-offline (only active connections)",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled", "forced-offline"}),
+			},
+		},
+	}
+}
+
+func resourceBigipLtmPoolAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	pool := fullPath(client.Partition, d.Get("pool").(string))
+	member := fullPath(client.Partition, d.Get("node").(string))
+
+	log.Println("[INFO] Adding member " + member + " to pool " + pool)
+	d.SetId(fmt.Sprintf("%s-%s", pool, member))
+	err := client.Transaction(func(tx *bigip.BigIP) error {
+		err := tx.CreatePoolMember(pool, &bigip.PoolMember{Name: member})
+		if err != nil {
+			return err
+		}
+		return resourceBigipLtmPoolAttachmentUpdate(d, tx)
+	})
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceBigipLtmPoolAttachmentRead(d, meta)
+}
+
+func resourceBigipLtmPoolAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	pool, member, err := parsePoolAttachmentId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Println("[INFO] Fetching member " + member + " of pool " + pool)
+
+	m, err := client.GetPoolMember(pool, member)
+	if err != nil {
+		return err
+	}
+	if m == nil {
+		return fmt.Errorf("Member %s not found in pool %s", member, pool)
+	}
+
+	state := "enabled"
+	if m.State == "user-down" {
+		state = "forced-offline"
+	} else if m.Session == "user-disabled" {
+		state = "disabled"
+	}
+
+	d.Set("pool", configuredPath(client.Partition, d.Get("pool").(string), pool))
+	d.Set("node", configuredPath(client.Partition, d.Get("node").(string), member))
+	d.Set("ratio", m.Ratio)
+	d.Set("dynamic_ratio", m.DynamicRatio)
+	d.Set("priority_group", m.PriorityGroup)
+	d.Set("connection_limit", m.ConnectionLimit)
+	d.Set("monitor", configuredPath(client.Partition, d.Get("monitor").(string), strings.TrimSpace(m.Monitor)))
+	d.Set("state", state)
+
+	return nil
+}
+
+func resourceBigipLtmPoolAttachmentExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client := meta.(*bigip.BigIP)
+
+	pool, member, err := parsePoolAttachmentId(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Println("[INFO] Checking member " + member + " of pool " + pool + " exists.")
+
+	m, err := client.GetPoolMember(pool, member)
+	if err != nil {
+		return false, err
+	}
+
+	if m == nil {
+		d.SetId("")
+	}
+	return m != nil, nil
+}
+
+func resourceBigipLtmPoolAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	pool, member, err := parsePoolAttachmentId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	m := &bigip.PoolMember{
+		Ratio:           d.Get("ratio").(int),
+		DynamicRatio:    d.Get("dynamic_ratio").(int),
+		PriorityGroup:   d.Get("priority_group").(int),
+		ConnectionLimit: d.Get("connection_limit").(int),
+		Monitor:         monitorPath(client.Partition, d.Get("monitor").(string)),
+	}
+
+	err = client.ModifyPoolMember(pool, member, m)
+	if err != nil {
+		return err
+	}
+
+	status := map[string]string{
+		"enabled":        "enable",
+		"disabled":       "disable",
+		"forced-offline": "offline",
+	}
+	return client.PoolMemberStatus(pool, member, status[d.Get("state").(string)])
+}
+
+func resourceBigipLtmPoolAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	pool, member, err := parsePoolAttachmentId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Println("[INFO] Removing member " + member + " from pool " + pool)
+
+	return client.DeletePoolMember(pool, member)
+}
+
+func resourceBigipLtmPoolAttachmentImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return []*schema.ResourceData{d}, nil
+}
+
+// Split an attachment ID into its pool and member names. Attachment IDs are
+// <pool>-<member>, e.g. /Common/pool1-/Common/web1:80. Names cannot contain a /
+// after the partition, so the split is unambiguous.
+func parsePoolAttachmentId(id string) (string, string, error) {
+	if i := strings.Index(id, "-/"); i >= 0 {
+		pool, member := id[:i], id[i+1:]
+		poolPartition, poolName := parseF5Identifier(pool)
+		memberPartition, memberName := parseF5Identifier(member)
+		if poolPartition != "" && memberPartition != "" &&
+			poolName != "" && memberName != "" && !strings.Contains(memberName, "/") {
+			return pool, member, nil
+		}
+	}
+	return "", "", fmt.Errorf("Invalid pool attachment ID %s, expected /Partition/pool-/Partition/node:port", id)
+}
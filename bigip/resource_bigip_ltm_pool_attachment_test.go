@@ -0,0 +1,202 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/scottdware/go-bigip"
+)
+
+var TEST_POOL_MEMBER_NAME = fmt.Sprintf("/%s/test-node:443", TEST_PARTITION)
+var TEST_POOL_MEMBER2_NAME = fmt.Sprintf("/%s/test-node2:443", TEST_PARTITION)
+
+var TEST_POOL_ATTACHMENT_POOL_RESOURCE = TEST_NODE_RESOURCE + `
+resource "bigip_ltm_node" "test-node2" {
+	name = "/` + TEST_PARTITION + `/test-node2"
+	address = "10.10.10.11"
+}
+
+resource "bigip_ltm_pool" "test-pool" {
+	name = "` + TEST_POOL_NAME + `"
+	monitors = ["/Common/http"]
+}
+`
+
+var TEST_POOL_ATTACHMENT_RESOURCE = TEST_POOL_ATTACHMENT_POOL_RESOURCE + `
+resource "bigip_ltm_pool_attachment" "test-member" {
+	pool = "${bigip_ltm_pool.test-pool.name}"
+	node = "${bigip_ltm_node.test-node.name}:443"
+}
+
+resource "bigip_ltm_pool_attachment" "test-member2" {
+	pool = "${bigip_ltm_pool.test-pool.name}"
+	node = "${bigip_ltm_node.test-node2.name}:443"
+	ratio = 2
+	priority_group = 5
+}
+`
+
+var TEST_POOL_ATTACHMENT_RESOURCE_UPDATED = TEST_POOL_ATTACHMENT_POOL_RESOURCE + `
+resource "bigip_ltm_pool_attachment" "test-member" {
+	pool = "${bigip_ltm_pool.test-pool.name}"
+	node = "${bigip_ltm_node.test-node.name}:443"
+	ratio = 3
+	dynamic_ratio = 4
+	priority_group = 10
+	connection_limit = 100
+	monitor = "/Common/tcp"
+	state = "disabled"
+}
+
+resource "bigip_ltm_pool_attachment" "test-member2" {
+	pool = "${bigip_ltm_pool.test-pool.name}"
+	node = "${bigip_ltm_node.test-node2.name}:443"
+	ratio = 2
+	priority_group = 5
+	state = "forced-offline"
+}
+`
+
+var TEST_POOL_ATTACHMENT_SHORT_RESOURCE = `
+provider "bigip" {
+	partition = "` + TEST_PARTITION + `"
+}
+` + TEST_POOL_ATTACHMENT_POOL_RESOURCE + `
+resource "bigip_ltm_pool_attachment" "test-member" {
+	pool = "test-pool"
+	node = "test-node:443"
+	depends_on = ["bigip_ltm_pool.test-pool", "bigip_ltm_node.test-node"]
+}
+`
+
+func TestBigipLtmPoolAttachment_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPoolAttachmentsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_POOL_ATTACHMENT_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolAttachmentExists(TEST_POOL_NAME, TEST_POOL_MEMBER_NAME, true),
+					testCheckPoolAttachmentExists(TEST_POOL_NAME, TEST_POOL_MEMBER2_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member", "id", TEST_POOL_NAME+"-"+TEST_POOL_MEMBER_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member", "ratio", "1"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member", "priority_group", "0"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member", "monitor", "default"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member", "state", "enabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member2", "ratio", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member2", "priority_group", "5"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_POOL_ATTACHMENT_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolAttachmentExists(TEST_POOL_NAME, TEST_POOL_MEMBER_NAME, true),
+					testCheckPoolAttachmentExists(TEST_POOL_NAME, TEST_POOL_MEMBER2_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member", "ratio", "3"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member", "dynamic_ratio", "4"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member", "priority_group", "10"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member", "connection_limit", "100"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member", "monitor", "/Common/tcp"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member", "state", "disabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member2", "state", "forced-offline"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_POOL_ATTACHMENT_POOL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolAttachmentExists(TEST_POOL_NAME, TEST_POOL_MEMBER_NAME, false),
+					testCheckPoolAttachmentExists(TEST_POOL_NAME, TEST_POOL_MEMBER2_NAME, false),
+					testCheckPoolExists(TEST_POOL_NAME, true),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipLtmPoolAttachment_shortNames(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPoolAttachmentsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_POOL_ATTACHMENT_SHORT_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolAttachmentExists(TEST_POOL_NAME, TEST_POOL_MEMBER_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member", "id", TEST_POOL_NAME+"-"+TEST_POOL_MEMBER_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member", "pool", "test-pool"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member", "node", "test-node:443"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipLtmPoolAttachment_import(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPoolAttachmentsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_POOL_ATTACHMENT_RESOURCE,
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_ltm_pool_attachment.test-member2",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckPoolAttachmentExists(pool, member string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		m, err := client.GetPoolMember(pool, member)
+		if err != nil {
+			return err
+		}
+		if exists && m == nil {
+			return fmt.Errorf("Member %s not found in pool %s", member, pool)
+		}
+		if !exists && m != nil {
+			return fmt.Errorf("Member %s still exists in pool %s", member, pool)
+		}
+		return nil
+	}
+}
+
+func testCheckPoolAttachmentsDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_ltm_pool_attachment" {
+			continue
+		}
+
+		pool, member, err := parsePoolAttachmentId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		m, err := client.GetPoolMember(pool, member)
+		if err != nil {
+			return err
+		}
+		if m != nil {
+			return fmt.Errorf("Member %s not destroyed", rs.Primary.ID)
+		}
+	}
+	return nil
+}
@@ -2,9 +2,12 @@ package bigip
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/scottdware/go-bigip"
 )
@@ -21,8 +24,56 @@ resource "bigip_ltm_pool" "test-pool" {
 }
 `
 
+var TEST_POOL_RESOURCE_UPDATED = `
+resource "bigip_ltm_pool" "test-pool" {
+	name = "` + TEST_POOL_NAME + `"
+	description = "test pool"
+	allow_nat = false
+	allow_snat = false
+	load_balancing_mode = "least-connections-member"
+	min_active_members = 2
+	min_up_members = 1
+	min_up_members_action = "reboot"
+	min_up_members_checking = true
+	slow_ramp_time = 0
+	service_down_action = "reselect"
+	reselect_tries = 3
+	queue_on_connection_limit = true
+	queue_depth_limit = 100
+	queue_time_limit = 5000
+	ip_tos_to_client = "mimic"
+	ip_tos_to_server = "16"
+	link_qos_to_client = "3"
+	link_qos_to_server = "pass-through"
+	ignore_persisted_weight = true
+}
+`
+
+var TEST_POOL_MIN_MONITORS_RESOURCE = `
+resource "bigip_ltm_pool" "test-pool" {
+	name = "` + TEST_POOL_NAME + `"
+	monitors = ["/Common/http", "/Common/tcp", "/Common/icmp"]
+	availability_requirement = 2
+}
+`
+
+var TEST_POOL_TOO_FEW_MONITORS_RESOURCE = `
+resource "bigip_ltm_pool" "test-pool" {
+	name = "` + TEST_POOL_NAME + `"
+	monitors = ["/Common/http"]
+	availability_requirement = 2
+}
+`
+
+var TEST_POOL_INVALID_MODE_RESOURCE = `
+resource "bigip_ltm_pool" "test-pool" {
+	name = "` + TEST_POOL_NAME + `"
+	load_balancing_mode = "round-robbin"
+}
+`
+
 func TestBigipLtmPool_create(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAcctPreCheck(t)
 		},
@@ -37,14 +88,101 @@ func TestBigipLtmPool_create(t *testing.T) {
 					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "allow_nat", "true"),
 					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "allow_snat", "true"),
 					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "load_balancing_mode", "round-robin"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "min_active_members", "0"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "slow_ramp_time", "10"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "service_down_action", "none"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "ip_tos_to_client", "pass-through"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_POOL_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolExists(TEST_POOL_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "description", "test pool"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "allow_nat", "false"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "allow_snat", "false"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "load_balancing_mode", "least-connections-member"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "monitors.#", "0"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "min_active_members", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "min_up_members", "1"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "min_up_members_action", "reboot"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "min_up_members_checking", "true"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "slow_ramp_time", "0"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "service_down_action", "reselect"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "reselect_tries", "3"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "queue_on_connection_limit", "true"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "queue_depth_limit", "100"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "queue_time_limit", "5000"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "ip_tos_to_client", "mimic"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "ip_tos_to_server", "16"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "link_qos_to_client", "3"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "link_qos_to_server", "pass-through"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "ignore_persisted_weight", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_POOL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "description", ""),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "min_active_members", "0"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "slow_ramp_time", "10"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "queue_on_connection_limit", "false"),
 				),
 			},
 		},
 	})
 }
 
+func TestBigipLtmPool_availabilityRequirement(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPoolsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_POOL_MIN_MONITORS_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolMonitor(TEST_POOL_NAME, "min 2 of { "),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "monitors.#", "3"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "availability_requirement", "2"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_POOL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolMonitor(TEST_POOL_NAME, "/Common/http"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "monitors.#", "1"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "availability_requirement", "0"),
+				),
+			},
+			resource.TestStep{
+				Config:      TEST_POOL_TOO_FEW_MONITORS_RESOURCE,
+				ExpectError: regexp.MustCompile("Availability requirement 2 is more than the 1 monitors"),
+			},
+		},
+	})
+}
+
+func TestBigipLtmPool_invalidMode(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPoolsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      TEST_POOL_INVALID_MODE_RESOURCE,
+				ExpectError: regexp.MustCompile("load_balancing_mode"),
+			},
+		},
+	})
+}
+
 func TestBigipLtmPool_import(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAcctPreCheck(t)
 		},
@@ -64,7 +202,83 @@ func TestBigipLtmPool_import(t *testing.T) {
 	})
 }
 
-//TODO: test adding/removing nodes
+func TestBigipLtmPool_nodes(t *testing.T) {
+	nodes := func(nodes string) string {
+		return TEST_NODE_RESOURCE + `
+resource "bigip_ltm_node" "test-node2" {
+	name = "/` + TEST_PARTITION + `/test-node2"
+	address = "10.10.10.11"
+}
+
+resource "bigip_ltm_pool" "test-pool" {
+	name = "` + TEST_POOL_NAME + `"
+	` + nodes + `
+	depends_on = ["bigip_ltm_node.test-node", "bigip_ltm_node.test-node2"]
+}
+`
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPoolsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: nodes(`nodes = ["test-node:80", "test-node2:80"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolMember(TEST_POOL_NAME, "test-node:80"),
+					testCheckPoolMember(TEST_POOL_NAME, "test-node2:80"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "nodes.#", "2"),
+				),
+			},
+			resource.TestStep{
+				Config: nodes(`nodes = ["test-node2:80"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolMember(TEST_POOL_NAME, "test-node2:80"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "nodes.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: nodes(`nodes = []`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckEmptyPool(TEST_POOL_NAME),
+				),
+			},
+			resource.TestStep{
+				Config: nodes(`nodes = ["test-node:80"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolMember(TEST_POOL_NAME, "test-node:80"),
+				),
+			},
+			// Members added by an attachment are left out of nodes, so they
+			// don't show up as changes and aren't removed
+			resource.TestStep{
+				Config: nodes(`nodes = ["test-node:80"]`) + `
+resource "bigip_ltm_pool_attachment" "test-member" {
+	pool = "${bigip_ltm_pool.test-pool.name}"
+	node = "/` + TEST_PARTITION + `/test-node2:80"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolMember(TEST_POOL_NAME, "test-node:80"),
+					testCheckPoolMember(TEST_POOL_NAME, "test-node2:80"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "nodes.#", "1"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool",
+						fmt.Sprintf("nodes.%d", schema.HashString("test-node:80")),
+						"test-node:80"),
+				),
+			},
+			resource.TestStep{
+				Config: nodes(``),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckEmptyPool(TEST_POOL_NAME),
+				),
+			},
+		},
+	})
+}
 
 func testCheckPoolExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
@@ -75,10 +289,25 @@ func testCheckPoolExists(name string, exists bool) resource.TestCheckFunc {
 			return err
 		}
 		if exists && p == nil {
-			return fmt.Errorf("Pool ", name, " does not exist.")
+			return fmt.Errorf("Pool %s does not exist.", name)
 		}
 		if !exists && p != nil {
-			return fmt.Errorf("Pool ", name, " exists.")
+			return fmt.Errorf("Pool %s exists.", name)
+		}
+		return nil
+	}
+}
+
+func testCheckPoolMonitor(poolName, prefix string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		p, err := client.GetPool(poolName)
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(p.Monitor, prefix) {
+			return fmt.Errorf("Pool %s monitor is %q, expected it to start with %q", poolName, p.Monitor, prefix)
 		}
 		return nil
 	}
@@ -132,7 +361,7 @@ func testCheckPoolsDestroyed(s *terraform.State) error {
 			return err
 		}
 		if pool != nil {
-			return fmt.Errorf("Pool ", name, " not destroyed.")
+			return fmt.Errorf("Pool %s not destroyed.", name)
 		}
 	}
 	return nil
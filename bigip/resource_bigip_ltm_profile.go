@@ -0,0 +1,330 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+// A profileType describes one of the ltm/profile collections. Every type has a
+// name and a parent, and adds its own attributes through schema, expand and
+// flatten, which convert between them and the type's go-bigip struct.
+type profileType struct {
+	// Collection name, e.g. client-ssl
+	uri string
+	// Description used in log messages, e.g. client SSL
+	description string
+	// Profile new profiles inherit from by default
+	parent string
+	schema map[string]*schema.Schema
+	// Whether attributes that aren't Computed take the parent's value when left
+	// out. They are sent as the parent's value, as BIG-IP would otherwise keep
+	// whatever was set last, and read back as unset while they still match it
+	inherit bool
+	// Empty profile of the type to read into, e.g. &bigip.ClientSSLProfile{}
+	profile func() interface{}
+	// expand fills in profile, as returned by the profile func, including its
+	// parent. flatten sets every attribute but the name from a profile read back
+	expand  func(d *schema.ResourceData, client *bigip.BigIP, profile interface{})
+	flatten func(d *schema.ResourceData, client *bigip.BigIP, profile interface{})
+}
+
+func resourceBigipLtmProfile(t *profileType) *schema.Resource {
+	s := map[string]*schema.Schema{
+		"name": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Name of the profile",
+			ForceNew:     true,
+			ValidateFunc: validateF5Name,
+		},
+
+		"parent": &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      t.parent,
+			Description:  "Profile to inherit settings from",
+			ValidateFunc: validateF5Name,
+		},
+	}
+	for k, v := range t.schema {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		Create: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceBigipLtmProfileCreate(t, d, meta)
+		},
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceBigipLtmProfileRead(t, d, meta)
+		},
+		Update: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceBigipLtmProfileUpdate(t, d, meta)
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceBigipLtmProfileDelete(t, d, meta)
+		},
+		Exists: func(d *schema.ResourceData, meta interface{}) (bool, error) {
+			return resourceBigipLtmProfileExists(t, d, meta)
+		},
+		Importer: &schema.ResourceImporter{
+			State: resourceBigipLtmProfileImporter,
+		},
+
+		Schema: s,
+	}
+}
+
+func resourceBigipLtmProfileCreate(t *profileType, d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := fullPath(client.Partition, d.Get("name").(string))
+
+	// The parent is read before the transaction, which can't hold reads
+	profile, err := expandProfile(t, d, client)
+	if err != nil {
+		return err
+	}
+
+	log.Println("[INFO] Creating " + t.description + " profile " + name)
+	d.SetId(name)
+	err = client.Transaction(func(tx *bigip.BigIP) error {
+		err := tx.CreateProfile(t.uri, name, fullPath(client.Partition, d.Get("parent").(string)))
+		if err != nil {
+			return err
+		}
+		return tx.ModifyProfile(t.uri, name, profile)
+	})
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceBigipLtmProfileRead(t, d, meta)
+}
+
+func resourceBigipLtmProfileRead(t *profileType, d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	log.Println("[INFO] Reading " + t.description + " profile " + name)
+
+	profile := t.profile()
+	found, err := client.ReadProfile(t.uri, name, profile)
+	if err != nil {
+		return err
+	}
+	if !found {
+		d.SetId("")
+		return nil
+	}
+
+	configured := make(map[string]bool)
+	for k := range t.schema {
+		_, configured[k] = d.GetOk(k)
+	}
+
+	d.Set("name", name)
+	t.flatten(d, client, profile)
+
+	if !t.inherit {
+		return nil
+	}
+	parent, err := readParentProfile(t, client, d.Get("parent").(string))
+	if err != nil {
+		return err
+	}
+	for k, s := range t.schema {
+		if !s.Computed && !configured[k] && profileValuesEqual(d.Get(k), parent.Get(k)) {
+			d.Set(k, nil)
+		}
+	}
+
+	return nil
+}
+
+func resourceBigipLtmProfileExists(t *profileType, d *schema.ResourceData, meta interface{}) (bool, error) {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Checking " + t.description + " profile " + name + " exists.")
+
+	found, err := client.ReadProfile(t.uri, name, t.profile())
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		d.SetId("")
+	}
+
+	return found, nil
+}
+
+func resourceBigipLtmProfileUpdate(t *profileType, d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	profile, err := expandProfile(t, d, client)
+	if err != nil {
+		return err
+	}
+
+	return client.ModifyProfile(t.uri, name, profile)
+}
+
+func resourceBigipLtmProfileDelete(t *profileType, d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting " + t.description + " profile " + name)
+
+	return client.DeleteProfile(t.uri, name)
+}
+
+func resourceBigipLtmProfileImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
+	return []*schema.ResourceData{d}, nil
+}
+
+// Build the profile to send. For a type that inherits, attributes left out of
+// the configuration are filled in from the parent
+func expandProfile(t *profileType, d *schema.ResourceData, client *bigip.BigIP) (interface{}, error) {
+	profile := t.profile()
+	if !t.inherit {
+		t.expand(d, client, profile)
+		return profile, nil
+	}
+
+	parent, err := readParentProfile(t, client, d.Get("parent").(string))
+	if err != nil {
+		return nil, err
+	}
+	// Built on empty data, as setting a set over the parent's can keep some of its elements
+	values := resourceBigipLtmProfile(t).Data(nil)
+	values.Set("parent", d.Get("parent"))
+	for k, s := range t.schema {
+		v, ok := d.GetOk(k)
+		if !ok && !s.Computed {
+			v = parent.Get(k)
+		}
+		values.Set(k, v)
+	}
+	t.expand(values, client, profile)
+	return profile, nil
+}
+
+// Read the named parent profile into resource data of the type, with the
+// parent attribute set to the parent itself
+func readParentProfile(t *profileType, client *bigip.BigIP, name string) (*schema.ResourceData, error) {
+	parent := t.profile()
+	found, err := client.ReadProfile(t.uri, fullPath(client.Partition, name), parent)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("Parent %s profile %s not found", t.description, name)
+	}
+
+	values := resourceBigipLtmProfile(t).Data(nil)
+	t.flatten(values, client, parent)
+	values.Set("parent", name)
+	return values, nil
+}
+
+func profileValuesEqual(a, b interface{}) bool {
+	if set, ok := a.(*schema.Set); ok {
+		return set.Equal(b)
+	}
+	return a == b
+}
+
+// Attributes shared by client and server SSL profiles
+func sslProfileSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cert": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Certificate the BIG-IP presents, e.g. /Common/example.crt",
+		},
+
+		"key": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Key for the certificate, e.g. /Common/example.key",
+		},
+
+		"chain": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Intermediate certificate bundle sent with the certificate",
+		},
+
+		"passphrase": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Passphrase for an encrypted key. Not read back from the BIG-IP",
+		},
+
+		"ciphers": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "OpenSSL style cipher string, e.g. DEFAULT:!SSLv3",
+		},
+
+		"options": &schema.Schema{
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Set:         schema.HashString,
+			Optional:    true,
+			Description: "SSL options, e.g. no-tlsv1. Empty sets none",
+		},
+
+		"server_name": &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "Server name for SNI: matched against the client's for client SSL, sent to the server for server SSL",
+		},
+
+		"sni_default": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Use this profile when no other profile of its type on the virtual server matches",
+		},
+
+		"sni_require": &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Require the peer to use SNI",
+		},
+	}
+}
+
+// SSL options to send: BIG-IP keeps the parent's options unless it is told none
+func expandSSLProfileOptions(d *schema.ResourceData) []string {
+	options := setToStringSlice(d.Get("options").(*schema.Set))
+	if len(options) == 0 {
+		return []string{"none"}
+	}
+	return options
+}
+
+func flattenSSLProfileOptions(options []string) *schema.Set {
+	if len(options) == 1 && options[0] == "none" {
+		options = nil
+	}
+	return makeStringSet(&options)
+}
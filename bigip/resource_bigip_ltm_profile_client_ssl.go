@@ -0,0 +1,53 @@
+package bigip
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipLtmProfileClientSSL() *schema.Resource {
+	return resourceBigipLtmProfile(profileTypeClientSSL())
+}
+
+func profileTypeClientSSL() *profileType {
+	return &profileType{
+		uri:         "client-ssl",
+		description: "client SSL",
+		parent:      "/Common/clientssl",
+		schema:      sslProfileSchema(),
+		profile: func() interface{} {
+			return &bigip.ClientSSLProfile{}
+		},
+		expand:  expandProfileClientSSL,
+		flatten: flattenProfileClientSSL,
+	}
+}
+
+func expandProfileClientSSL(d *schema.ResourceData, client *bigip.BigIP, p interface{}) {
+	profile := p.(*bigip.ClientSSLProfile)
+	profile.DefaultsFrom = fullPath(client.Partition, d.Get("parent").(string))
+	profile.Cert = fullPath(client.Partition, d.Get("cert").(string))
+	profile.Key = fullPath(client.Partition, d.Get("key").(string))
+	profile.Chain = fullPath(client.Partition, d.Get("chain").(string))
+	profile.Passphrase = d.Get("passphrase").(string)
+	profile.Ciphers = d.Get("ciphers").(string)
+	profile.Options = expandSSLProfileOptions(d)
+	profile.ServerName = d.Get("server_name").(string)
+	profile.SniDefault = strconv.FormatBool(d.Get("sni_default").(bool))
+	profile.SniRequire = strconv.FormatBool(d.Get("sni_require").(bool))
+}
+
+func flattenProfileClientSSL(d *schema.ResourceData, client *bigip.BigIP, p interface{}) {
+	profile := p.(*bigip.ClientSSLProfile)
+	d.Set("parent", configuredPath(client.Partition, d.Get("parent").(string), profile.DefaultsFrom))
+	d.Set("cert", configuredPath(client.Partition, d.Get("cert").(string), profile.Cert))
+	d.Set("key", configuredPath(client.Partition, d.Get("key").(string), profile.Key))
+	d.Set("chain", configuredPath(client.Partition, d.Get("chain").(string), profile.Chain))
+	d.Set("ciphers", profile.Ciphers)
+	d.Set("options", flattenSSLProfileOptions(profile.Options))
+	d.Set("server_name", profile.ServerName)
+	d.Set("sni_default", profile.SniDefault == "true")
+	d.Set("sni_require", profile.SniRequire == "true")
+}
@@ -0,0 +1,151 @@
+package bigip
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/scottdware/go-bigip"
+)
+
+var TEST_CLIENT_SSL_NAME = fmt.Sprintf("/%s/test-client-ssl", TEST_PARTITION)
+
+var TEST_CLIENT_SSL_RESOURCE = `
+resource "bigip_ltm_profile_client_ssl" "test-client-ssl" {
+	name = "` + TEST_CLIENT_SSL_NAME + `"
+}
+`
+
+var TEST_CLIENT_SSL_RESOURCE_UPDATED = `
+resource "bigip_ltm_profile_client_ssl" "test-client-ssl" {
+	name = "` + TEST_CLIENT_SSL_NAME + `"
+	cert = "/Common/default.crt"
+	key = "/Common/default.key"
+	ciphers = "DEFAULT:!SSLv3:!RC4"
+	options = ["dont-insert-empty-fragments", "no-tlsv1"]
+	server_name = "www.example.com"
+	sni_default = true
+}
+`
+
+func TestBigipLtmProfileClientSSL_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckClientSSLProfilesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_CLIENT_SSL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckClientSSLProfileExists(TEST_CLIENT_SSL_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-client-ssl", "name", TEST_CLIENT_SSL_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-client-ssl", "parent", "/Common/clientssl"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-client-ssl", "cert", "/Common/default.crt"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-client-ssl", "ciphers", "DEFAULT"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-client-ssl", "sni_default", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_CLIENT_SSL_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckClientSSLProfileExists(TEST_CLIENT_SSL_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-client-ssl", "ciphers", "DEFAULT:!SSLv3:!RC4"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-client-ssl", "options.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-client-ssl",
+						fmt.Sprintf("options.%d", schema.HashString("no-tlsv1")),
+						"no-tlsv1"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-client-ssl", "server_name", "www.example.com"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-client-ssl", "sni_default", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_CLIENT_SSL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckClientSSLProfileOptions(TEST_CLIENT_SSL_NAME, []string{"none"}),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-client-ssl", "options.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipLtmProfileClientSSL_import(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckClientSSLProfilesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_CLIENT_SSL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckClientSSLProfileExists(TEST_CLIENT_SSL_NAME, true),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_ltm_profile_client_ssl.test-client-ssl",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckClientSSLProfileExists(name string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		profile, err := client.GetClientSSLProfile(name)
+		if err != nil {
+			return err
+		}
+		if exists && profile == nil {
+			return fmt.Errorf("Client SSL profile %s does not exist.", name)
+		}
+		if !exists && profile != nil {
+			return fmt.Errorf("Client SSL profile %s exists.", name)
+		}
+		return nil
+	}
+}
+
+func testCheckClientSSLProfileOptions(name string, options []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		profile, err := client.GetClientSSLProfile(name)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(profile.Options, options) {
+			return fmt.Errorf("Client SSL profile %s has options %v, expected %v", name, profile.Options, options)
+		}
+		return nil
+	}
+}
+
+func testCheckClientSSLProfilesDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_ltm_profile_client_ssl" {
+			continue
+		}
+
+		name := rs.Primary.ID
+		profile, err := client.GetClientSSLProfile(name)
+		if err != nil {
+			return err
+		}
+		if profile != nil {
+			return fmt.Errorf("Client SSL profile %s not destroyed.", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package bigip
+
+import (
+	"math"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipLtmProfileFastL4() *schema.Resource {
+	return resourceBigipLtmProfile(profileTypeFastL4())
+}
+
+func profileTypeFastL4() *profileType {
+	return &profileType{
+		uri:         "fastl4",
+		description: "FastL4",
+		parent:      "/Common/fastL4",
+		inherit:     true,
+		profile: func() interface{} {
+			return &bigip.FastL4Profile{}
+		},
+		expand:  expandProfileFastL4,
+		flatten: flattenProfileFastL4,
+		schema: map[string]*schema.Schema{
+			"idle_timeout": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Seconds an idle connection is kept, immediate or indefinite",
+				ValidateFunc: validateKeywordOrIntRange([]string{"immediate", "indefinite"}, 0, math.MaxInt32),
+			},
+
+			"handshake_timeout": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Seconds allowed for the TCP handshake, immediate or indefinite",
+				ValidateFunc: validateKeywordOrIntRange([]string{"immediate", "indefinite"}, 0, math.MaxInt32),
+			},
+
+			"loose_initialization": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "enabled to accept connections that start with a packet other than SYN",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+
+			"loose_close": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "enabled to remove a connection as soon as the first FIN is seen",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+
+			"reset_on_timeout": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "enabled to send a RST when a connection times out",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+
+			"late_binding": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "enabled to delay choosing a pool member until the first payload",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+
+			"pva_acceleration": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Hardware acceleration: full, partial, none or guaranteed",
+				ValidateFunc: validateStringValue([]string{"full", "partial", "none", "guaranteed"}),
+			},
+
+			"mss_override": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum segment size advertised to both sides. 0 leaves it unchanged",
+				ValidateFunc: validateIntRange(0, 9162),
+			},
+
+			"receive_window_size": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Receive window size in bytes. 0 leaves it unchanged",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+		},
+	}
+}
+
+func expandProfileFastL4(d *schema.ResourceData, client *bigip.BigIP, p interface{}) {
+	profile := p.(*bigip.FastL4Profile)
+	profile.DefaultsFrom = fullPath(client.Partition, d.Get("parent").(string))
+	profile.IdleTimeout = d.Get("idle_timeout").(string)
+	profile.TCPHandshakeTimeout = d.Get("handshake_timeout").(string)
+	profile.LooseInitialization = d.Get("loose_initialization").(string)
+	profile.LooseClose = d.Get("loose_close").(string)
+	profile.ResetOnTimeout = d.Get("reset_on_timeout").(string)
+	profile.LateBinding = d.Get("late_binding").(string)
+	profile.PvaAcceleration = d.Get("pva_acceleration").(string)
+	profile.MssOverride = d.Get("mss_override").(int)
+	profile.ReceiveWindowSize = d.Get("receive_window_size").(int)
+}
+
+func flattenProfileFastL4(d *schema.ResourceData, client *bigip.BigIP, p interface{}) {
+	profile := p.(*bigip.FastL4Profile)
+	d.Set("parent", configuredPath(client.Partition, d.Get("parent").(string), profile.DefaultsFrom))
+	d.Set("idle_timeout", profile.IdleTimeout)
+	d.Set("handshake_timeout", profile.TCPHandshakeTimeout)
+	d.Set("loose_initialization", profile.LooseInitialization)
+	d.Set("loose_close", profile.LooseClose)
+	d.Set("reset_on_timeout", profile.ResetOnTimeout)
+	d.Set("late_binding", profile.LateBinding)
+	d.Set("pva_acceleration", profile.PvaAcceleration)
+	d.Set("mss_override", profile.MssOverride)
+	d.Set("receive_window_size", profile.ReceiveWindowSize)
+}
@@ -0,0 +1,76 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+var TEST_FASTL4_PROFILE_NAME = fmt.Sprintf("/%s/test-fastl4", TEST_PARTITION)
+
+var TEST_FASTL4_PROFILE_RESOURCE = `
+resource "bigip_ltm_profile_fastl4" "test-fastl4" {
+	name = "` + TEST_FASTL4_PROFILE_NAME + `"
+}
+`
+
+var TEST_FASTL4_PROFILE_RESOURCE_UPDATED = `
+resource "bigip_ltm_profile_fastl4" "test-fastl4" {
+	name = "` + TEST_FASTL4_PROFILE_NAME + `"
+	idle_timeout = "indefinite"
+	handshake_timeout = "10"
+	loose_initialization = "enabled"
+	loose_close = "enabled"
+	pva_acceleration = "none"
+}
+`
+
+func TestBigipLtmProfileFastL4_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckProfilesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_FASTL4_PROFILE_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckProfileExists("fastl4", TEST_FASTL4_PROFILE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "parent", "/Common/fastL4"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "idle_timeout", ""),
+					testCheckProfileValue("fastl4", TEST_FASTL4_PROFILE_NAME, "idleTimeout", "300"),
+					testCheckProfileValue("fastl4", TEST_FASTL4_PROFILE_NAME, "pvaAcceleration", "full"),
+					testCheckProfileValue("fastl4", TEST_FASTL4_PROFILE_NAME, "looseClose", "disabled"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_FASTL4_PROFILE_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckProfileExists("fastl4", TEST_FASTL4_PROFILE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "idle_timeout", "indefinite"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "handshake_timeout", "10"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "loose_initialization", "enabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "loose_close", "enabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "pva_acceleration", "none"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_ltm_profile_fastl4.test-fastl4",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				Config: TEST_FASTL4_PROFILE_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "idle_timeout", ""),
+					testCheckProfileValue("fastl4", TEST_FASTL4_PROFILE_NAME, "idleTimeout", "300"),
+					testCheckProfileValue("fastl4", TEST_FASTL4_PROFILE_NAME, "tcpHandshakeTimeout", "5"),
+					testCheckProfileValue("fastl4", TEST_FASTL4_PROFILE_NAME, "looseClose", "disabled"),
+					testCheckProfileValue("fastl4", TEST_FASTL4_PROFILE_NAME, "pvaAcceleration", "full"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,131 @@
+package bigip
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipLtmProfileHTTP() *schema.Resource {
+	return resourceBigipLtmProfile(profileTypeHTTP())
+}
+
+func profileTypeHTTP() *profileType {
+	return &profileType{
+		uri:         "http",
+		description: "HTTP",
+		parent:      "/Common/http",
+		inherit:     true,
+		profile: func() interface{} {
+			return &bigip.HTTPProfile{}
+		},
+		expand:  expandProfileHTTP,
+		flatten: flattenProfileHTTP,
+		schema: map[string]*schema.Schema{
+			"insert_xforwarded_for": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "enabled to add an X-Forwarded-For header with the client address",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+
+			"accept_xff": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "enabled to trust X-Forwarded-For headers from clients as the client address",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+
+			"redirect_rewrite": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Which server redirects to rewrite to the virtual server address: none, all, matching or nodes",
+				ValidateFunc: validateStringValue([]string{"none", "all", "matching", "nodes"}),
+			},
+
+			"fallback_host": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Host to redirect clients to when no pool member is available",
+			},
+
+			"fallback_status_codes": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+				Description: "Server response codes that also redirect to fallback_host, e.g. 500",
+			},
+
+			"server_agent_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Value of the Server header in responses",
+			},
+
+			"header_insert": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Header inserted in every request, e.g. X-Via: bigip",
+			},
+
+			"basic_auth_realm": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Realm sent in basic authentication challenges",
+			},
+
+			"request_chunking": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "How chunked requests are handled: preserve, selective, rechunk or sustain",
+				ValidateFunc: validateStringValue([]string{"preserve", "selective", "rechunk", "sustain"}),
+			},
+
+			"response_chunking": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "How chunked responses are handled: preserve, selective, rechunk, sustain or unchunk",
+				ValidateFunc: validateStringValue([]string{"preserve", "selective", "rechunk", "sustain", "unchunk"}),
+			},
+
+			"oneconnect_transformations": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "enabled to rewrite Connection: close headers so OneConnect can reuse server connections",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+		},
+	}
+}
+
+func expandProfileHTTP(d *schema.ResourceData, client *bigip.BigIP, p interface{}) {
+	profile := p.(*bigip.HTTPProfile)
+	profile.DefaultsFrom = fullPath(client.Partition, d.Get("parent").(string))
+	profile.InsertXForwardedFor = d.Get("insert_xforwarded_for").(string)
+	profile.AcceptXFF = d.Get("accept_xff").(string)
+	profile.RedirectRewrite = d.Get("redirect_rewrite").(string)
+	profile.FallbackHost = d.Get("fallback_host").(string)
+	profile.FallbackStatusCodes = setToStringSlice(d.Get("fallback_status_codes").(*schema.Set))
+	profile.ServerAgentName = d.Get("server_agent_name").(string)
+	profile.HeaderInsert = d.Get("header_insert").(string)
+	profile.BasicAuthRealm = d.Get("basic_auth_realm").(string)
+	profile.RequestChunking = d.Get("request_chunking").(string)
+	profile.ResponseChunking = d.Get("response_chunking").(string)
+	profile.OneconnectTransformations = d.Get("oneconnect_transformations").(string)
+}
+
+func flattenProfileHTTP(d *schema.ResourceData, client *bigip.BigIP, p interface{}) {
+	profile := p.(*bigip.HTTPProfile)
+	d.Set("parent", configuredPath(client.Partition, d.Get("parent").(string), profile.DefaultsFrom))
+	d.Set("insert_xforwarded_for", profile.InsertXForwardedFor)
+	d.Set("accept_xff", profile.AcceptXFF)
+	d.Set("redirect_rewrite", profile.RedirectRewrite)
+	d.Set("fallback_host", profile.FallbackHost)
+	d.Set("fallback_status_codes", makeStringSet(&profile.FallbackStatusCodes))
+	d.Set("server_agent_name", profile.ServerAgentName)
+	d.Set("header_insert", profile.HeaderInsert)
+	d.Set("basic_auth_realm", profile.BasicAuthRealm)
+	d.Set("request_chunking", profile.RequestChunking)
+	d.Set("response_chunking", profile.ResponseChunking)
+	d.Set("oneconnect_transformations", profile.OneconnectTransformations)
+}
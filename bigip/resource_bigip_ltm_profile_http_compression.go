@@ -0,0 +1,167 @@
+package bigip
+
+import (
+	"math"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipLtmProfileHTTPCompression() *schema.Resource {
+	return resourceBigipLtmProfile(profileTypeHTTPCompression())
+}
+
+func profileTypeHTTPCompression() *profileType {
+	return &profileType{
+		uri:         "http-compression",
+		description: "HTTP compression",
+		parent:      "/Common/httpcompression",
+		inherit:     true,
+		profile: func() interface{} {
+			return &bigip.HTTPCompressionProfile{}
+		},
+		expand:  expandProfileHTTPCompression,
+		flatten: flattenProfileHTTPCompression,
+		schema: map[string]*schema.Schema{
+			"content_type_include": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+				Description: "Content types to compress, e.g. text/",
+			},
+
+			"content_type_exclude": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+				Description: "Content types never compressed",
+			},
+
+			"uri_include": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+				Description: "URIs to compress, as regular expressions",
+			},
+
+			"uri_exclude": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+				Description: "URIs never compressed, as regular expressions",
+			},
+
+			"min_size": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Minimum response size in bytes to compress",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+
+			"buffer_size": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Bytes of a response buffered before compressing",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+
+			"gzip_level": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "gzip compression level, 1 (fastest) to 9 (smallest)",
+				ValidateFunc: validateIntRange(1, 9),
+			},
+
+			"gzip_memory_level": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Kilobytes of memory used for gzip compression state",
+				ValidateFunc: validateIntRange(1, 256),
+			},
+
+			"gzip_window_size": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Kilobytes of gzip window",
+				ValidateFunc: validateIntRange(1, 128),
+			},
+
+			"method_prefer": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Compression method used when the client accepts both: gzip or deflate",
+				ValidateFunc: validateStringValue([]string{"gzip", "deflate"}),
+			},
+
+			"cpu_saver": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "enabled to stop compressing when CPU usage is high",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+
+			"keep_accept_encoding": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "enabled to let servers compress responses themselves",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+
+			"vary_header": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "enabled to add Vary: Accept-Encoding to compressed responses",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+
+			"allow_http_10": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "enabled to compress responses to HTTP/1.0 requests",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+		},
+	}
+}
+
+func expandProfileHTTPCompression(d *schema.ResourceData, client *bigip.BigIP, p interface{}) {
+	profile := p.(*bigip.HTTPCompressionProfile)
+	profile.DefaultsFrom = fullPath(client.Partition, d.Get("parent").(string))
+	profile.ContentTypeInclude = setToStringSlice(d.Get("content_type_include").(*schema.Set))
+	profile.ContentTypeExclude = setToStringSlice(d.Get("content_type_exclude").(*schema.Set))
+	profile.URIInclude = setToStringSlice(d.Get("uri_include").(*schema.Set))
+	profile.URIExclude = setToStringSlice(d.Get("uri_exclude").(*schema.Set))
+	profile.MinSize = d.Get("min_size").(int)
+	profile.BufferSize = d.Get("buffer_size").(int)
+	profile.GzipLevel = d.Get("gzip_level").(int)
+	profile.GzipMemoryLevel = d.Get("gzip_memory_level").(int)
+	profile.GzipWindowSize = d.Get("gzip_window_size").(int)
+	profile.MethodPrefer = d.Get("method_prefer").(string)
+	profile.CPUSaver = d.Get("cpu_saver").(string)
+	profile.KeepAcceptEncoding = d.Get("keep_accept_encoding").(string)
+	profile.VaryHeader = d.Get("vary_header").(string)
+	profile.AllowHTTP10 = d.Get("allow_http_10").(string)
+}
+
+func flattenProfileHTTPCompression(d *schema.ResourceData, client *bigip.BigIP, p interface{}) {
+	profile := p.(*bigip.HTTPCompressionProfile)
+	d.Set("parent", configuredPath(client.Partition, d.Get("parent").(string), profile.DefaultsFrom))
+	d.Set("content_type_include", makeStringSet(&profile.ContentTypeInclude))
+	d.Set("content_type_exclude", makeStringSet(&profile.ContentTypeExclude))
+	d.Set("uri_include", makeStringSet(&profile.URIInclude))
+	d.Set("uri_exclude", makeStringSet(&profile.URIExclude))
+	d.Set("min_size", profile.MinSize)
+	d.Set("buffer_size", profile.BufferSize)
+	d.Set("gzip_level", profile.GzipLevel)
+	d.Set("gzip_memory_level", profile.GzipMemoryLevel)
+	d.Set("gzip_window_size", profile.GzipWindowSize)
+	d.Set("method_prefer", profile.MethodPrefer)
+	d.Set("cpu_saver", profile.CPUSaver)
+	d.Set("keep_accept_encoding", profile.KeepAcceptEncoding)
+	d.Set("vary_header", profile.VaryHeader)
+	d.Set("allow_http_10", profile.AllowHTTP10)
+}
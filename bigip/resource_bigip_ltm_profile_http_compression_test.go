@@ -0,0 +1,79 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+var TEST_HTTP_COMPRESSION_PROFILE_NAME = fmt.Sprintf("/%s/test-http-compression", TEST_PARTITION)
+
+var TEST_HTTP_COMPRESSION_PROFILE_RESOURCE = `
+resource "bigip_ltm_profile_http_compression" "test-http-compression" {
+	name = "` + TEST_HTTP_COMPRESSION_PROFILE_NAME + `"
+}
+`
+
+var TEST_HTTP_COMPRESSION_PROFILE_RESOURCE_UPDATED = `
+resource "bigip_ltm_profile_http_compression" "test-http-compression" {
+	name = "` + TEST_HTTP_COMPRESSION_PROFILE_NAME + `"
+	content_type_include = ["text/", "application/json"]
+	uri_exclude = ["/download/.*"]
+	min_size = 2048
+	gzip_level = 6
+	method_prefer = "deflate"
+	vary_header = "disabled"
+}
+`
+
+func TestBigipLtmProfileHTTPCompression_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckProfilesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_HTTP_COMPRESSION_PROFILE_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckProfileExists("http-compression", TEST_HTTP_COMPRESSION_PROFILE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.test-http-compression", "parent", "/Common/httpcompression"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.test-http-compression", "content_type_include.#", "0"),
+					testCheckProfileValue("http-compression", TEST_HTTP_COMPRESSION_PROFILE_NAME, "gzipLevel", 1),
+					testCheckProfileValue("http-compression", TEST_HTTP_COMPRESSION_PROFILE_NAME, "contentTypeInclude", []string{"text/", "application/(xml|x-javascript)"}),
+					testCheckProfileValue("http-compression", TEST_HTTP_COMPRESSION_PROFILE_NAME, "methodPrefer", "gzip"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_HTTP_COMPRESSION_PROFILE_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckProfileExists("http-compression", TEST_HTTP_COMPRESSION_PROFILE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.test-http-compression", "content_type_include.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.test-http-compression", "uri_exclude.#", "1"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.test-http-compression", "min_size", "2048"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.test-http-compression", "gzip_level", "6"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.test-http-compression", "method_prefer", "deflate"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.test-http-compression", "vary_header", "disabled"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_ltm_profile_http_compression.test-http-compression",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				Config: TEST_HTTP_COMPRESSION_PROFILE_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.test-http-compression", "content_type_include.#", "0"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.test-http-compression", "uri_exclude.#", "0"),
+					testCheckProfileValue("http-compression", TEST_HTTP_COMPRESSION_PROFILE_NAME, "contentTypeInclude", []string{"text/", "application/(xml|x-javascript)"}),
+					testCheckProfileValue("http-compression", TEST_HTTP_COMPRESSION_PROFILE_NAME, "uriExclude", []string{}),
+					testCheckProfileValue("http-compression", TEST_HTTP_COMPRESSION_PROFILE_NAME, "gzipLevel", 1),
+					testCheckProfileValue("http-compression", TEST_HTTP_COMPRESSION_PROFILE_NAME, "varyHeader", "enabled"),
+				),
+			},
+		},
+	})
+}
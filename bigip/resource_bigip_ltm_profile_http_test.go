@@ -0,0 +1,183 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/scottdware/go-bigip"
+)
+
+// Profile collection for each resource type
+var profileResourceTypes = map[string]string{
+	"bigip_ltm_profile_http":             "http",
+	"bigip_ltm_profile_tcp":              "tcp",
+	"bigip_ltm_profile_fastl4":           "fastl4",
+	"bigip_ltm_profile_oneconnect":       "one-connect",
+	"bigip_ltm_profile_http_compression": "http-compression",
+}
+
+var TEST_HTTP_PROFILE_NAME = fmt.Sprintf("/%s/test-http", TEST_PARTITION)
+
+var TEST_HTTP_PROFILE_RESOURCE = `
+resource "bigip_ltm_profile_http" "test-http" {
+	name = "` + TEST_HTTP_PROFILE_NAME + `"
+}
+`
+
+var TEST_HTTP_PROFILE_RESOURCE_UPDATED = `
+resource "bigip_ltm_profile_http" "test-http" {
+	name = "` + TEST_HTTP_PROFILE_NAME + `"
+	insert_xforwarded_for = "enabled"
+	redirect_rewrite = "matching"
+	fallback_host = "http://sorry.example.com/"
+	fallback_status_codes = ["500", "503"]
+	server_agent_name = "app"
+	response_chunking = "rechunk"
+}
+`
+
+func TestBigipLtmProfileHTTP_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckProfilesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_HTTP_PROFILE_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckProfileExists("http", TEST_HTTP_PROFILE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http.test-http", "parent", "/Common/http"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http.test-http", "server_agent_name", ""),
+					testCheckProfileValue("http", TEST_HTTP_PROFILE_NAME, "serverAgentName", "BigIP"),
+					testCheckProfileValue("http", TEST_HTTP_PROFILE_NAME, "insertXforwardedFor", "disabled"),
+					testCheckProfileValue("http", TEST_HTTP_PROFILE_NAME, "requestChunking", "preserve"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_HTTP_PROFILE_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckProfileExists("http", TEST_HTTP_PROFILE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http.test-http", "insert_xforwarded_for", "enabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http.test-http", "redirect_rewrite", "matching"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http.test-http", "fallback_host", "http://sorry.example.com/"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http.test-http", "fallback_status_codes.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http.test-http", "server_agent_name", "app"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http.test-http", "response_chunking", "rechunk"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_ltm_profile_http.test-http",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				Config: TEST_HTTP_PROFILE_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http.test-http", "server_agent_name", ""),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http.test-http", "fallback_status_codes.#", "0"),
+					testCheckProfileValue("http", TEST_HTTP_PROFILE_NAME, "serverAgentName", "BigIP"),
+					testCheckProfileValue("http", TEST_HTTP_PROFILE_NAME, "insertXforwardedFor", "disabled"),
+					testCheckProfileValue("http", TEST_HTTP_PROFILE_NAME, "fallbackHost", ""),
+					testCheckProfileValue("http", TEST_HTTP_PROFILE_NAME, "responseChunking", "selective"),
+				),
+			},
+		},
+	})
+}
+
+var TEST_VS_PROTOCOL_PROFILES_RESOURCE = TEST_HTTP_PROFILE_RESOURCE + TEST_TCP_PROFILE_RESOURCE + TEST_ONECONNECT_PROFILE_RESOURCE + `
+resource "bigip_ltm_virtual_server" "test-vs" {
+	name = "` + TEST_VS_NAME + `"
+	destination = "10.255.255.254"
+	port = 9999
+	profiles = ["${bigip_ltm_profile_http.test-http.name}", "${bigip_ltm_profile_oneconnect.test-oneconnect.name}"]
+	client_profiles = ["${bigip_ltm_profile_tcp.test-tcp.name}"]
+}
+`
+
+func TestBigipLtmProfileHTTP_virtualServer(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckVSsDestroyed,
+			testCheckProfilesDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_VS_PROTOCOL_PROFILES_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVSExists(TEST_VS_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "profiles.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "client_profiles.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckProfileExists(profileType, name string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		profile, err := client.GetProfile(profileType, name)
+		if err != nil {
+			return err
+		}
+		if exists && profile == nil {
+			return fmt.Errorf("%s profile %s does not exist.", profileType, name)
+		}
+		if !exists && profile != nil {
+			return fmt.Errorf("%s profile %s exists.", profileType, name)
+		}
+		return nil
+	}
+}
+
+// Check a profile's value for key on the BIG-IP, e.g. one it inherits, which
+// isn't kept in state
+func testCheckProfileValue(profileType, name, key string, value interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		var profile map[string]interface{}
+		found, err := client.ReadProfile(profileType, name, &profile)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("%s profile %s does not exist.", profileType, name)
+		}
+		if fmt.Sprint(profile[key]) != fmt.Sprint(value) {
+			return fmt.Errorf("%s profile %s has %s %v, expected %v", profileType, name, key, profile[key], value)
+		}
+		return nil
+	}
+}
+
+func testCheckProfilesDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		profileType, ok := profileResourceTypes[rs.Type]
+		if !ok {
+			continue
+		}
+
+		name := rs.Primary.ID
+		profile, err := client.GetProfile(profileType, name)
+		if err != nil {
+			return err
+		}
+		if profile != nil {
+			return fmt.Errorf("%s profile %s not destroyed.", profileType, name)
+		}
+	}
+	return nil
+}
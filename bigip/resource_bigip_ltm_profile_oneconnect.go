@@ -0,0 +1,99 @@
+package bigip
+
+import (
+	"math"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipLtmProfileOneConnect() *schema.Resource {
+	return resourceBigipLtmProfile(profileTypeOneConnect())
+}
+
+func profileTypeOneConnect() *profileType {
+	return &profileType{
+		uri:         "one-connect",
+		description: "OneConnect",
+		parent:      "/Common/oneconnect",
+		inherit:     true,
+		profile: func() interface{} {
+			return &bigip.OneConnectProfile{}
+		},
+		expand:  expandProfileOneConnect,
+		flatten: flattenProfileOneConnect,
+		schema: map[string]*schema.Schema{
+			"source_mask": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Mask applied to client addresses when picking a server connection to reuse, e.g. 255.255.255.0",
+			},
+
+			"max_size": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of idle server connections kept for reuse",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+
+			"max_age": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum seconds a server connection is reused for",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+
+			"max_reuse": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Maximum number of requests sent over one server connection",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+
+			"idle_timeout_override": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Seconds an idle server connection is kept for reuse, disabled or indefinite",
+				ValidateFunc: validateKeywordOrIntRange([]string{"disabled", "indefinite"}, 0, math.MaxInt32),
+			},
+
+			"limit_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "How connection limits count idle connections: none, idle or strict",
+				ValidateFunc: validateStringValue([]string{"none", "idle", "strict"}),
+			},
+
+			"share_pools": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "enabled to reuse server connections across pools",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+		},
+	}
+}
+
+func expandProfileOneConnect(d *schema.ResourceData, client *bigip.BigIP, p interface{}) {
+	profile := p.(*bigip.OneConnectProfile)
+	profile.DefaultsFrom = fullPath(client.Partition, d.Get("parent").(string))
+	profile.SourceMask = d.Get("source_mask").(string)
+	profile.MaxSize = d.Get("max_size").(int)
+	profile.MaxAge = d.Get("max_age").(int)
+	profile.MaxReuse = d.Get("max_reuse").(int)
+	profile.IdleTimeoutOverride = d.Get("idle_timeout_override").(string)
+	profile.LimitType = d.Get("limit_type").(string)
+	profile.SharePools = d.Get("share_pools").(string)
+}
+
+func flattenProfileOneConnect(d *schema.ResourceData, client *bigip.BigIP, p interface{}) {
+	profile := p.(*bigip.OneConnectProfile)
+	d.Set("parent", configuredPath(client.Partition, d.Get("parent").(string), profile.DefaultsFrom))
+	d.Set("source_mask", profile.SourceMask)
+	d.Set("max_size", profile.MaxSize)
+	d.Set("max_age", profile.MaxAge)
+	d.Set("max_reuse", profile.MaxReuse)
+	d.Set("idle_timeout_override", profile.IdleTimeoutOverride)
+	d.Set("limit_type", profile.LimitType)
+	d.Set("share_pools", profile.SharePools)
+}
@@ -0,0 +1,78 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+var TEST_ONECONNECT_PROFILE_NAME = fmt.Sprintf("/%s/test-oneconnect", TEST_PARTITION)
+
+var TEST_ONECONNECT_PROFILE_RESOURCE = `
+resource "bigip_ltm_profile_oneconnect" "test-oneconnect" {
+	name = "` + TEST_ONECONNECT_PROFILE_NAME + `"
+}
+`
+
+var TEST_ONECONNECT_PROFILE_RESOURCE_UPDATED = `
+resource "bigip_ltm_profile_oneconnect" "test-oneconnect" {
+	name = "` + TEST_ONECONNECT_PROFILE_NAME + `"
+	source_mask = "255.255.255.0"
+	max_size = 500
+	max_age = 3600
+	max_reuse = 100
+	idle_timeout_override = "60"
+	share_pools = "enabled"
+}
+`
+
+func TestBigipLtmProfileOneConnect_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckProfilesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_ONECONNECT_PROFILE_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckProfileExists("one-connect", TEST_ONECONNECT_PROFILE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "parent", "/Common/oneconnect"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "source_mask", ""),
+					testCheckProfileValue("one-connect", TEST_ONECONNECT_PROFILE_NAME, "sourceMask", "any"),
+					testCheckProfileValue("one-connect", TEST_ONECONNECT_PROFILE_NAME, "maxSize", 10000),
+					testCheckProfileValue("one-connect", TEST_ONECONNECT_PROFILE_NAME, "limitType", "none"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_ONECONNECT_PROFILE_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckProfileExists("one-connect", TEST_ONECONNECT_PROFILE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "source_mask", "255.255.255.0"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "max_size", "500"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "max_age", "3600"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "max_reuse", "100"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "idle_timeout_override", "60"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "share_pools", "enabled"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_ltm_profile_oneconnect.test-oneconnect",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				Config: TEST_ONECONNECT_PROFILE_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "source_mask", ""),
+					testCheckProfileValue("one-connect", TEST_ONECONNECT_PROFILE_NAME, "sourceMask", "any"),
+					testCheckProfileValue("one-connect", TEST_ONECONNECT_PROFILE_NAME, "maxSize", 10000),
+					testCheckProfileValue("one-connect", TEST_ONECONNECT_PROFILE_NAME, "idleTimeoutOverride", "disabled"),
+					testCheckProfileValue("one-connect", TEST_ONECONNECT_PROFILE_NAME, "sharePools", "disabled"),
+				),
+			},
+		},
+	})
+}
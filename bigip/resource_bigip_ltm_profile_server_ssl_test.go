@@ -0,0 +1,128 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/scottdware/go-bigip"
+)
+
+var TEST_SERVER_SSL_NAME = fmt.Sprintf("/%s/test-server-ssl", TEST_PARTITION)
+
+var TEST_SERVER_SSL_RESOURCE = `
+resource "bigip_ltm_profile_server_ssl" "test-server-ssl" {
+	name = "` + TEST_SERVER_SSL_NAME + `"
+}
+`
+
+var TEST_SERVER_SSL_RESOURCE_UPDATED = `
+resource "bigip_ltm_profile_server_ssl" "test-server-ssl" {
+	name = "` + TEST_SERVER_SSL_NAME + `"
+	cert = "/Common/default.crt"
+	key = "/Common/default.key"
+	ciphers = "DEFAULT:!SSLv3:!RC4"
+	options = ["dont-insert-empty-fragments", "no-tlsv1"]
+	server_name = "www.example.com"
+	sni_default = true
+}
+`
+
+func TestBigipLtmProfileServerSSL_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckServerSSLProfilesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_SERVER_SSL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckServerSSLProfileExists(TEST_SERVER_SSL_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-server-ssl", "name", TEST_SERVER_SSL_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-server-ssl", "parent", "/Common/serverssl"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-server-ssl", "cert", "none"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-server-ssl", "ciphers", "DEFAULT"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-server-ssl", "sni_default", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_SERVER_SSL_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckServerSSLProfileExists(TEST_SERVER_SSL_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-server-ssl", "ciphers", "DEFAULT:!SSLv3:!RC4"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-server-ssl", "options.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-server-ssl",
+						fmt.Sprintf("options.%d", schema.HashString("no-tlsv1")),
+						"no-tlsv1"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-server-ssl", "server_name", "www.example.com"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-server-ssl", "sni_default", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipLtmProfileServerSSL_import(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckServerSSLProfilesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_SERVER_SSL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckServerSSLProfileExists(TEST_SERVER_SSL_NAME, true),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_ltm_profile_server_ssl.test-server-ssl",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckServerSSLProfileExists(name string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		profile, err := client.GetServerSSLProfile(name)
+		if err != nil {
+			return err
+		}
+		if exists && profile == nil {
+			return fmt.Errorf("Server SSL profile %s does not exist.", name)
+		}
+		if !exists && profile != nil {
+			return fmt.Errorf("Server SSL profile %s exists.", name)
+		}
+		return nil
+	}
+}
+
+func testCheckServerSSLProfilesDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_ltm_profile_server_ssl" {
+			continue
+		}
+
+		name := rs.Primary.ID
+		profile, err := client.GetServerSSLProfile(name)
+		if err != nil {
+			return err
+		}
+		if profile != nil {
+			return fmt.Errorf("Server SSL profile %s not destroyed.", name)
+		}
+	}
+	return nil
+}
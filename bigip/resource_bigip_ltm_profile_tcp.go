@@ -0,0 +1,144 @@
+package bigip
+
+import (
+	"math"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipLtmProfileTCP() *schema.Resource {
+	return resourceBigipLtmProfile(profileTypeTCP())
+}
+
+func profileTypeTCP() *profileType {
+	return &profileType{
+		uri:         "tcp",
+		description: "TCP",
+		parent:      "/Common/tcp",
+		inherit:     true,
+		profile: func() interface{} {
+			return &bigip.TCPProfile{}
+		},
+		expand:  expandProfileTCP,
+		flatten: flattenProfileTCP,
+		schema: map[string]*schema.Schema{
+			"idle_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Seconds an idle connection is kept open",
+				ValidateFunc: validateIntRange(1, math.MaxInt32),
+			},
+
+			"close_wait_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Seconds a connection stays in CLOSE-WAIT",
+				ValidateFunc: validateIntRange(1, math.MaxInt32),
+			},
+
+			"fin_wait_timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Seconds a connection stays in FIN-WAIT",
+				ValidateFunc: validateIntRange(1, math.MaxInt32),
+			},
+
+			"keepalive_interval": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Seconds between keep-alive probes",
+				ValidateFunc: validateIntRange(1, math.MaxInt32),
+			},
+
+			"nagle": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Nagle's algorithm: enabled, disabled or auto",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled", "auto"}),
+			},
+
+			"delayed_acks": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "enabled to delay ACKs",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+
+			"selective_acks": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "enabled to negotiate selective ACKs",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled"}),
+			},
+
+			"congestion_control": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Congestion control algorithm, e.g. high-speed or woodside",
+			},
+
+			"send_buffer_size": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Send buffer size in bytes",
+				ValidateFunc: validateIntRange(1, math.MaxInt32),
+			},
+
+			"receive_window_size": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Receive window size in bytes",
+				ValidateFunc: validateIntRange(1, math.MaxInt32),
+			},
+
+			"proxy_buffer_high": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Bytes buffered before the other side of the connection is told to stop sending",
+				ValidateFunc: validateIntRange(1, math.MaxInt32),
+			},
+
+			"proxy_buffer_low": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Bytes buffered before the other side of the connection is told to resume",
+				ValidateFunc: validateIntRange(1, math.MaxInt32),
+			},
+		},
+	}
+}
+
+func expandProfileTCP(d *schema.ResourceData, client *bigip.BigIP, p interface{}) {
+	profile := p.(*bigip.TCPProfile)
+	profile.DefaultsFrom = fullPath(client.Partition, d.Get("parent").(string))
+	profile.IdleTimeout = d.Get("idle_timeout").(int)
+	profile.CloseWaitTimeout = d.Get("close_wait_timeout").(int)
+	profile.FinWaitTimeout = d.Get("fin_wait_timeout").(int)
+	profile.KeepAliveInterval = d.Get("keepalive_interval").(int)
+	profile.Nagle = d.Get("nagle").(string)
+	profile.DelayedAcks = d.Get("delayed_acks").(string)
+	profile.SelectiveAcks = d.Get("selective_acks").(string)
+	profile.CongestionControl = d.Get("congestion_control").(string)
+	profile.SendBufferSize = d.Get("send_buffer_size").(int)
+	profile.ReceiveWindowSize = d.Get("receive_window_size").(int)
+	profile.ProxyBufferHigh = d.Get("proxy_buffer_high").(int)
+	profile.ProxyBufferLow = d.Get("proxy_buffer_low").(int)
+}
+
+func flattenProfileTCP(d *schema.ResourceData, client *bigip.BigIP, p interface{}) {
+	profile := p.(*bigip.TCPProfile)
+	d.Set("parent", configuredPath(client.Partition, d.Get("parent").(string), profile.DefaultsFrom))
+	d.Set("idle_timeout", profile.IdleTimeout)
+	d.Set("close_wait_timeout", profile.CloseWaitTimeout)
+	d.Set("fin_wait_timeout", profile.FinWaitTimeout)
+	d.Set("keepalive_interval", profile.KeepAliveInterval)
+	d.Set("nagle", profile.Nagle)
+	d.Set("delayed_acks", profile.DelayedAcks)
+	d.Set("selective_acks", profile.SelectiveAcks)
+	d.Set("congestion_control", profile.CongestionControl)
+	d.Set("send_buffer_size", profile.SendBufferSize)
+	d.Set("receive_window_size", profile.ReceiveWindowSize)
+	d.Set("proxy_buffer_high", profile.ProxyBufferHigh)
+	d.Set("proxy_buffer_low", profile.ProxyBufferLow)
+}
@@ -0,0 +1,86 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+var TEST_TCP_PROFILE_NAME = fmt.Sprintf("/%s/test-tcp", TEST_PARTITION)
+
+var TEST_TCP_PROFILE_RESOURCE = `
+resource "bigip_ltm_profile_tcp" "test-tcp" {
+	name = "` + TEST_TCP_PROFILE_NAME + `"
+}
+`
+
+var TEST_TCP_PROFILE_RESOURCE_UPDATED = `
+resource "bigip_ltm_profile_tcp" "test-tcp" {
+	name = "` + TEST_TCP_PROFILE_NAME + `"
+	parent = "/Common/tcp-wan-optimized"
+	idle_timeout = 600
+	keepalive_interval = 60
+	nagle = "auto"
+	congestion_control = "woodside"
+	send_buffer_size = 262144
+}
+`
+
+var TEST_TCP_PROFILE_RESOURCE_CLEARED = `
+resource "bigip_ltm_profile_tcp" "test-tcp" {
+	name = "` + TEST_TCP_PROFILE_NAME + `"
+	parent = "/Common/tcp-wan-optimized"
+}
+`
+
+func TestBigipLtmProfileTCP_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckProfilesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_TCP_PROFILE_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckProfileExists("tcp", TEST_TCP_PROFILE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "parent", "/Common/tcp"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "nagle", ""),
+					testCheckProfileValue("tcp", TEST_TCP_PROFILE_NAME, "idleTimeout", 300),
+					testCheckProfileValue("tcp", TEST_TCP_PROFILE_NAME, "nagle", "enabled"),
+					testCheckProfileValue("tcp", TEST_TCP_PROFILE_NAME, "congestionControl", "high-speed"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_TCP_PROFILE_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckProfileExists("tcp", TEST_TCP_PROFILE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "parent", "/Common/tcp-wan-optimized"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "idle_timeout", "600"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "keepalive_interval", "60"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "nagle", "auto"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "congestion_control", "woodside"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "send_buffer_size", "262144"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_ltm_profile_tcp.test-tcp",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				Config: TEST_TCP_PROFILE_RESOURCE_CLEARED,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "parent", "/Common/tcp-wan-optimized"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "nagle", ""),
+					testCheckProfileValue("tcp", TEST_TCP_PROFILE_NAME, "idleTimeout", 300),
+					testCheckProfileValue("tcp", TEST_TCP_PROFILE_NAME, "nagle", "disabled"),
+					testCheckProfileValue("tcp", TEST_TCP_PROFILE_NAME, "congestionControl", "high-speed"),
+					testCheckProfileValue("tcp", TEST_TCP_PROFILE_NAME, "sendBufferSize", 131072),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,126 @@
+package bigip
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipLtmSnatPool() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipLtmSnatPoolCreate,
+		Read:   resourceBigipLtmSnatPoolRead,
+		Update: resourceBigipLtmSnatPoolUpdate,
+		Delete: resourceBigipLtmSnatPoolDelete,
+		Exists: resourceBigipLtmSnatPoolExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceBigipLtmSnatPoolImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the SNAT pool",
+				ForceNew:     true,
+				ValidateFunc: validateF5Name,
+			},
+
+			"members": &schema.Schema{
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validatePartitionedAddress,
+				},
+				Set:         schema.HashString,
+				Required:    true,
+				Description: "Translation addresses, e.g. /Common/10.1.1.1 or 10.1.1.1",
+			},
+		},
+	}
+}
+
+func resourceBigipLtmSnatPoolCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := fullPath(client.Partition, d.Get("name").(string))
+
+	log.Println("[INFO] Creating SNAT pool " + name)
+	err := client.CreateSnatPool(name, fullPaths(client.Partition, setToStringSlice(d.Get("members").(*schema.Set))))
+	if err != nil {
+		return err
+	}
+	d.SetId(name)
+
+	return resourceBigipLtmSnatPoolRead(d, meta)
+}
+
+func resourceBigipLtmSnatPoolRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	log.Println("[INFO] Reading SNAT pool " + name)
+
+	snatpool, err := client.GetSnatPool(name)
+	if err != nil {
+		return err
+	}
+
+	members := configuredPaths(client.Partition, setToStringSlice(d.Get("members").(*schema.Set)), snatpool.Members)
+	d.Set("name", name)
+	d.Set("members", makeStringSet(&members))
+
+	return nil
+}
+
+func resourceBigipLtmSnatPoolExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Checking SNAT pool " + name + " exists.")
+
+	snatpool, err := client.GetSnatPool(name)
+	if err != nil {
+		return false, err
+	}
+
+	if snatpool == nil {
+		d.SetId("")
+	}
+
+	return snatpool != nil, nil
+}
+
+func resourceBigipLtmSnatPoolUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	snatpool := &bigip.SnatPool{
+		Members: fullPaths(client.Partition, setToStringSlice(d.Get("members").(*schema.Set))),
+	}
+
+	err := client.ModifySnatPool(name, snatpool)
+	if err != nil {
+		return err
+	}
+
+	return resourceBigipLtmSnatPoolRead(d, meta)
+}
+
+func resourceBigipLtmSnatPoolDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting SNAT pool " + name)
+
+	return client.DeleteSnatPool(name)
+}
+
+func resourceBigipLtmSnatPoolImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,191 @@
+package bigip
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/scottdware/go-bigip"
+)
+
+var TEST_SNATPOOL_NAME = fmt.Sprintf("/%s/test-snatpool", TEST_PARTITION)
+
+var TEST_SNATPOOL_RESOURCE = `
+resource "bigip_ltm_snatpool" "test-snatpool" {
+	name = "` + TEST_SNATPOOL_NAME + `"
+	members = ["/` + TEST_PARTITION + `/10.20.20.1", "/` + TEST_PARTITION + `/10.20.20.2"]
+}
+`
+
+var TEST_SNATPOOL_RESOURCE_UPDATED = `
+resource "bigip_ltm_snatpool" "test-snatpool" {
+	name = "` + TEST_SNATPOOL_NAME + `"
+	members = ["/` + TEST_PARTITION + `/10.20.20.2", "/` + TEST_PARTITION + `/10.20.20.3"]
+}
+
+resource "bigip_ltm_virtual_server" "test-vs" {
+	name = "` + TEST_VS_NAME + `"
+	destination = "10.255.255.254"
+	port = 9999
+	source_address_translation = "snat"
+	snatpool = "${bigip_ltm_snatpool.test-snatpool.name}"
+}
+`
+
+var TEST_SNATPOOL_SHORT_RESOURCE = `
+provider "bigip" {
+	partition = "` + TEST_PARTITION + `"
+}
+
+resource "bigip_ltm_snatpool" "test-snatpool" {
+	name = "test-snatpool"
+	members = ["10.20.20.1", "/` + TEST_PARTITION + `/10.20.20.2"]
+}
+`
+
+func TestBigipLtmSnatPool_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckVSsDestroyed,
+			testCheckSnatPoolsDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_SNATPOOL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSnatPoolExists(TEST_SNATPOOL_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_snatpool.test-snatpool", "name", TEST_SNATPOOL_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_snatpool.test-snatpool", "members.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_snatpool.test-snatpool",
+						fmt.Sprintf("members.%d", schema.HashString("/"+TEST_PARTITION+"/10.20.20.1")),
+						"/"+TEST_PARTITION+"/10.20.20.1"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_SNATPOOL_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSnatPoolExists(TEST_SNATPOOL_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_snatpool.test-snatpool", "members.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_snatpool.test-snatpool",
+						fmt.Sprintf("members.%d", schema.HashString("/"+TEST_PARTITION+"/10.20.20.3")),
+						"/"+TEST_PARTITION+"/10.20.20.3"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "source_address_translation", "snat"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "snatpool", TEST_SNATPOOL_NAME),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipLtmSnatPool_shortMembers(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckSnatPoolsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_SNATPOOL_SHORT_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSnatPoolMembers(TEST_SNATPOOL_NAME, "/"+TEST_PARTITION+"/10.20.20.1", "/"+TEST_PARTITION+"/10.20.20.2"),
+					resource.TestCheckResourceAttr("bigip_ltm_snatpool.test-snatpool",
+						fmt.Sprintf("members.%d", schema.HashString("10.20.20.1")),
+						"10.20.20.1"),
+					resource.TestCheckResourceAttr("bigip_ltm_snatpool.test-snatpool",
+						fmt.Sprintf("members.%d", schema.HashString("/"+TEST_PARTITION+"/10.20.20.2")),
+						"/"+TEST_PARTITION+"/10.20.20.2"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipLtmSnatPool_import(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckSnatPoolsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_SNATPOOL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSnatPoolExists(TEST_SNATPOOL_NAME, true),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_ltm_snatpool.test-snatpool",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckSnatPoolExists(name string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		snatpool, err := client.GetSnatPool(name)
+		if err != nil {
+			return err
+		}
+		if exists && snatpool == nil {
+			return fmt.Errorf("SNAT pool %s does not exist.", name)
+		}
+		if !exists && snatpool != nil {
+			return fmt.Errorf("SNAT pool %s exists.", name)
+		}
+		return nil
+	}
+}
+
+func testCheckSnatPoolMembers(name string, members ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		snatpool, err := client.GetSnatPool(name)
+		if err != nil {
+			return err
+		}
+		if snatpool == nil {
+			return fmt.Errorf("SNAT pool %s does not exist.", name)
+		}
+		sort.Strings(snatpool.Members)
+		sort.Strings(members)
+		if !reflect.DeepEqual(snatpool.Members, members) {
+			return fmt.Errorf("SNAT pool %s members are %v, expected %v", name, snatpool.Members, members)
+		}
+		return nil
+	}
+}
+
+func testCheckSnatPoolsDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_ltm_snatpool" {
+			continue
+		}
+
+		name := rs.Primary.ID
+		snatpool, err := client.GetSnatPool(name)
+		if err != nil {
+			return err
+		}
+		if snatpool != nil {
+			return fmt.Errorf("SNAT pool %s not destroyed.", name)
+		}
+	}
+	return nil
+}
@@ -23,8 +23,8 @@ func resourceBigipLtmVirtualAddress() *schema.Resource {
 			"name": &schema.Schema{
 				Type:         schema.TypeString,
 				Required:     true,
-				Description:  "Name of the virtual address",
-				ValidateFunc: validateF5Name,
+				Description:  "Name of the virtual address, e.g. /Common/10.1.1.1 or /Common/2001:db8::1%2",
+				ValidateFunc: validateVirtualAddressName,
 			},
 
 			"arp": &schema.Schema{
@@ -74,7 +74,7 @@ func resourceBigipLtmVirtualAddress() *schema.Resource {
 				Optional:     true,
 				Default:      "/Common/traffic-group-1",
 				Description:  "Specify the partition and traffic group",
-				ValidateFunc: validateF5Name,
+				ValidateFunc: validateF5FullPath,
 			},
 		},
 	}
@@ -83,7 +83,7 @@ func resourceBigipLtmVirtualAddress() *schema.Resource {
 func resourceBigipLtmVirtualAddressCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*bigip.BigIP)
 
-	name := d.Get("name").(string)
+	name := fullPath(client.Partition, d.Get("name").(string))
 	log.Println("[INFO] Creating virtual address " + name)
 
 	client.CreateVirtualAddress(name, hydrateVirtualAddress(d))
@@ -179,12 +179,14 @@ func hydrateVirtualAddress(d *schema.ResourceData) *bigip.VirtualAddress {
 }
 
 func resourceBigipLtmVirtualAddressDelete(d *schema.ResourceData, meta interface{}) error {
-	name := d.Get("name").(string)
-	log.Printf("[INFO] Deleting virtual address " + name)
+	name := d.Id()
+	log.Println("[INFO] Deleting virtual address " + name)
 	client := meta.(*bigip.BigIP)
 	return client.DeleteVirtualAddress(name)
 }
 
 func resourceBigipLtmVirtualAddressImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
 	return []*schema.ResourceData{d}, nil
 }
@@ -20,7 +20,7 @@ resource "bigip_ltm_virtual_address" "test-va" {
 `
 
 func TestBigipLtmVA_create(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAcctPreCheck(t)
 		},
@@ -37,8 +37,35 @@ func TestBigipLtmVA_create(t *testing.T) {
 	})
 }
 
+var TEST_VA_IPV6_NAME = fmt.Sprintf("/%s/2001:db8::10", TEST_PARTITION)
+
+var TEST_VA_IPV6_RESOURCE = `
+resource "bigip_ltm_virtual_address" "test-va" {
+	name = "` + TEST_VA_IPV6_NAME + `"
+}
+`
+
+func TestBigipLtmVA_ipv6(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckVAsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_VA_IPV6_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVAExists(TEST_VA_IPV6_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_address.test-va", "name", TEST_VA_IPV6_NAME),
+				),
+			},
+		},
+	})
+}
+
 func TestBigipLtmVA_import(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAcctPreCheck(t)
 		},
@@ -70,14 +97,14 @@ func testCheckVAExists(name string, exists bool) resource.TestCheckFunc {
 		for _, va := range vas.VirtualAddresses {
 			if va.FullPath == name {
 				if !exists {
-					return fmt.Errorf("Virtual address " + name + " exists.")
+					return fmt.Errorf("Virtual address %s exists.", name)
 				} else {
 					return nil
 				}
 			}
 		}
 		if exists {
-			return fmt.Errorf("Virtual address " + name + " does not exist.")
+			return fmt.Errorf("Virtual address %s does not exist.", name)
 		}
 
 		return nil
@@ -99,7 +126,7 @@ func testCheckVAsDestroyed(s *terraform.State) error {
 		}
 		for _, va := range vas.VirtualAddresses {
 			if va.FullPath == name {
-				return fmt.Errorf("Virtual address ", name, " not destroyed.")
+				return fmt.Errorf("Virtual address %s not destroyed.", name)
 			}
 		}
 	}
@@ -1,10 +1,10 @@
 package bigip
 
 import (
-	"fmt"
 	"log"
-	"regexp"
-	"strings"
+	"math"
+	"sort"
+	"strconv"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/scottdware/go-bigip"
@@ -30,9 +30,10 @@ func resourceBigipLtmVirtualServer() *schema.Resource {
 			},
 
 			"port": &schema.Schema{
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Listen port for the virtual server",
+				Type:         schema.TypeInt,
+				Required:     true,
+				Description:  "Listen port for the virtual server. 0 listens on any port",
+				ValidateFunc: validateIntRange(0, 65535),
 			},
 
 			"source": &schema.Schema{
@@ -43,8 +44,10 @@ func resourceBigipLtmVirtualServer() *schema.Resource {
 			},
 
 			"destination": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Destination IP. May include a route domain, e.g. 10.1.1.1%2",
+				ValidateFunc: validateIPAddress,
 			},
 
 			"pool": &schema.Schema{
@@ -86,10 +89,10 @@ func resourceBigipLtmVirtualServer() *schema.Resource {
 			},
 
 			"irules": &schema.Schema{
-				Type:     schema.TypeSet,
-				Elem:     &schema.Schema{Type: schema.TypeString},
-				Set:      schema.HashString,
-				Optional: true,
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validateF5Name},
+				Optional:    true,
+				Description: "iRules attached to the virtual server, in the order their events run",
 			},
 
 			"source_address_translation": &schema.Schema{
@@ -100,10 +103,11 @@ func resourceBigipLtmVirtualServer() *schema.Resource {
 			},
 
 			"snatpool": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				Computed:    true,
-				Description: "Name of the snatpool to use. Requires source_address_translation to be set to 'snat'.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Name of the snatpool to use. Requires source_address_translation to be set to 'snat'.",
+				ValidateFunc: validateF5Name,
 			},
 
 			"ip_protocol": &schema.Schema{
@@ -126,6 +130,138 @@ func resourceBigipLtmVirtualServer() *schema.Resource {
 				Set:      schema.HashString,
 				Optional: true,
 			},
+
+			"vlans_enabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Listen only on the vlans listed in vlans. When false the virtual server listens on every vlan except those listed",
+			},
+
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User defined description",
+			},
+
+			"enabled": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Accept traffic. A disabled virtual server keeps its configuration but rejects new connections",
+			},
+
+			"source_port": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "preserve",
+				Description:  "Source port of connections to the servers: preserve, preserve-strict or change",
+				ValidateFunc: validateStringValue([]string{"preserve", "preserve-strict", "change"}),
+			},
+
+			"connection_limit": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Maximum concurrent connections. 0 means unlimited",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+
+			"rate_limit": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Maximum new connections per second. 0 means unlimited",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+
+			"rate_limit_mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "object",
+				Description:  "What rate_limit applies to: object, object-source, object-destination, object-source-destination, source, destination or source-destination",
+				ValidateFunc: validateStringValue([]string{"object", "object-source", "object-destination", "object-source-destination", "source", "destination", "source-destination"}),
+			},
+
+			"rate_limit_source_mask": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "CIDR mask grouping source addresses for source rate limit modes",
+				ValidateFunc: validateIntRange(0, 128),
+			},
+
+			"rate_limit_destination_mask": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "CIDR mask grouping destination addresses for destination rate limit modes",
+				ValidateFunc: validateIntRange(0, 128),
+			},
+
+			"translate_address": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Translate the destination address to the pool member address",
+			},
+
+			"translate_port": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Translate the destination port to the pool member port",
+			},
+
+			"mirror": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Mirror connection and persistence state to the peer device",
+			},
+
+			"auto_lasthop": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "default",
+				Description:  "Return traffic to the MAC address it arrived from: default, enabled or disabled",
+				ValidateFunc: validateStringValue([]string{"default", "enabled", "disabled"}),
+			},
+
+			"nat64": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Translate IPv6 client connections to IPv4 servers",
+			},
+
+			"gtm_score": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "Score used by GTM when load balancing to this virtual server",
+				ValidateFunc: validateIntRange(0, math.MaxInt32),
+			},
+
+			"syn_cookie_status": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Whether SYN cookie protection is currently active",
+			},
+
+			"persistence_profiles": &schema.Schema{
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validateF5Name},
+				Optional:    true,
+				Description: "Persistence profiles, e.g. /Common/cookie. The first one is the primary profile",
+			},
+
+			"fallback_persistence_profile": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Persistence profile used when the primary persistence profile finds no record, e.g. /Common/source_addr",
+				ValidateFunc: validateF5Name,
+			},
 		},
 	}
 }
@@ -133,26 +269,26 @@ func resourceBigipLtmVirtualServer() *schema.Resource {
 func resourceBigipLtmVirtualServerCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*bigip.BigIP)
 
-	name := d.Get("name").(string)
+	name := fullPath(client.Partition, d.Get("name").(string))
 	port := d.Get("port").(int)
 
 	log.Println("[INFO] Creating virtual server " + name)
-	err := client.CreateVirtualServer(
-		name,
-		d.Get("destination").(string),
-		d.Get("mask").(string),
-		d.Get("pool").(string),
-		port,
-	)
-	if err != nil {
-		return err
-	}
-
 	d.SetId(name)
-
-	err = resourceBigipLtmVirtualServerUpdate(d, meta)
+	err := client.Transaction(func(tx *bigip.BigIP) error {
+		err := tx.CreateVirtualServer(
+			name,
+			d.Get("destination").(string),
+			d.Get("mask").(string),
+			fullPath(client.Partition, d.Get("pool").(string)),
+			port,
+		)
+		if err != nil {
+			return err
+		}
+		return resourceBigipLtmVirtualServerUpdate(d, tx)
+	})
 	if err != nil {
-		client.DeleteVirtualServer(name)
+		d.SetId("")
 		return err
 	}
 
@@ -171,27 +307,62 @@ func resourceBigipLtmVirtualServerRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
-	// /Common/virtual_server_name:80
-	regex := regexp.MustCompile("(/\\w+/)?([\\w._-]+)(:\\d+)?")
-	destination := regex.FindStringSubmatch(vs.Destination)
-	if len(destination) < 4 {
-		return fmt.Errorf("Unknown virtual server destination: " + vs.Destination)
+	_, destination, port, err := bigip.ParseDestination(vs.Destination)
+	if err != nil {
+		return err
+	}
+	rateLimit, _ := strconv.Atoi(vs.RateLimit)
+
+	// The primary persistence profile is listed first, the others in the order they are configured
+	configuredPersistence := listToStringSlice(d.Get("persistence_profiles").([]interface{}))
+	persist := append([]bigip.VirtualServerPersistence(nil), vs.Persist...)
+	sort.SliceStable(persist, func(i, j int) bool {
+		if persist[i].Primary != persist[j].Primary {
+			return persist[i].Primary == "true"
+		}
+		return indexOfPath(client.Partition, configuredPersistence, fullPath(persist[i].Partition, persist[i].Name)) <
+			indexOfPath(client.Partition, configuredPersistence, fullPath(persist[j].Partition, persist[j].Name))
+	})
+	persistence := make([]string, 0, len(persist))
+	for _, p := range persist {
+		persistence = append(persistence, fullPath(p.Partition, p.Name))
+	}
+	persistence = configuredPaths(client.Partition, configuredPersistence, persistence)
+	fallbackPersistence := vs.FallbackPersistence
+	if fallbackPersistence == "none" {
+		fallbackPersistence = ""
 	}
 
-	pool := strings.Split(vs.Pool, "/")
-	d.Set("destination", destination[2])
+	d.Set("destination", destination)
 	d.Set("source", vs.Source)
-	d.Set("protocol", vs.IPProtocol)
 	d.Set("name", name)
-	d.Set("pool", pool[len(pool)-1])
+	d.Set("pool", vs.Pool)
 	d.Set("mask", vs.Mask)
-	d.Set("port", vs.SourcePort)
-	d.Set("irules", makeStringSet(&vs.Rules))
+	d.Set("port", port)
+	d.Set("irules", configuredPaths(client.Partition, listToStringSlice(d.Get("irules").([]interface{})), vs.Rules))
 	d.Set("ip_protocol", vs.IPProtocol)
 	d.Set("source_address_translation", vs.SourceAddressTranslation.Type)
-	d.Set("snatpool", vs.SourceAddressTranslation.Pool)
-	d.Set("policies", vs.Policies)
-	d.Set("vlans", vs.Vlans)
+	d.Set("snatpool", configuredPath(client.Partition, d.Get("snatpool").(string), vs.SourceAddressTranslation.Pool))
+	d.Set("policies", configuredPaths(client.Partition, setToStringSlice(d.Get("policies").(*schema.Set)), vs.Policies))
+	d.Set("vlans", configuredPaths(client.Partition, setToStringSlice(d.Get("vlans").(*schema.Set)), vs.Vlans))
+	d.Set("vlans_enabled", vs.VlansEnabled)
+	d.Set("description", vs.Description)
+	d.Set("enabled", !vs.Disabled)
+	d.Set("source_port", vs.SourcePort)
+	d.Set("connection_limit", vs.ConnectionLimit)
+	d.Set("rate_limit", rateLimit)
+	d.Set("rate_limit_mode", vs.RateLimitMode)
+	d.Set("rate_limit_source_mask", vs.RateLimitSourceMask)
+	d.Set("rate_limit_destination_mask", vs.RateLimitDestinationMask)
+	d.Set("translate_address", vs.TranslateAddress == "enabled")
+	d.Set("translate_port", vs.TranslatePort == "enabled")
+	d.Set("mirror", vs.Mirror == "enabled")
+	d.Set("auto_lasthop", vs.AutoLastHop)
+	d.Set("nat64", vs.NAT64 == "enabled")
+	d.Set("gtm_score", vs.GTMScore)
+	d.Set("syn_cookie_status", vs.SYNCookieStatus)
+	d.Set("persistence_profiles", persistence)
+	d.Set("fallback_persistence_profile", configuredPath(client.Partition, d.Get("fallback_persistence_profile").(string), fallbackPersistence))
 
 	profiles, err := client.VirtualServerProfiles(name)
 	if err != nil {
@@ -199,29 +370,29 @@ func resourceBigipLtmVirtualServerRead(d *schema.ResourceData, meta interface{})
 	}
 
 	if profiles != nil && len(profiles.Profiles) > 0 {
-		profile_names := schema.NewSet(schema.HashString, make([]interface{}, 0, len(profiles.Profiles)))
-		client_profile_names := schema.NewSet(schema.HashString, make([]interface{}, 0, len(profiles.Profiles)))
-		server_profile_names := schema.NewSet(schema.HashString, make([]interface{}, 0, len(profiles.Profiles)))
+		profile_names := make([]string, 0, len(profiles.Profiles))
+		client_profile_names := make([]string, 0, len(profiles.Profiles))
+		server_profile_names := make([]string, 0, len(profiles.Profiles))
 		for _, profile := range profiles.Profiles {
 			switch profile.Context {
 			case bigip.CONTEXT_CLIENT:
-				client_profile_names.Add(profile.FullPath)
+				client_profile_names = append(client_profile_names, profile.FullPath)
 				break
 			case bigip.CONTEXT_SERVER:
-				server_profile_names.Add(profile.FullPath)
+				server_profile_names = append(server_profile_names, profile.FullPath)
 				break
 			default:
-				profile_names.Add(profile.FullPath)
+				profile_names = append(profile_names, profile.FullPath)
 			}
 		}
-		if profile_names.Len() > 0 {
-			d.Set("profiles", profile_names)
+		if len(profile_names) > 0 {
+			d.Set("profiles", configuredPaths(client.Partition, setToStringSlice(d.Get("profiles").(*schema.Set)), profile_names))
 		}
-		if client_profile_names.Len() > 0 {
-			d.Set("client_profiles", client_profile_names)
+		if len(client_profile_names) > 0 {
+			d.Set("client_profiles", configuredPaths(client.Partition, setToStringSlice(d.Get("client_profiles").(*schema.Set)), client_profile_names))
 		}
-		if server_profile_names.Len() > 0 {
-			d.Set("server_profiles", server_profile_names)
+		if len(server_profile_names) > 0 {
+			d.Set("server_profiles", configuredPaths(client.Partition, setToStringSlice(d.Get("server_profiles").(*schema.Set)), server_profile_names))
 		}
 	}
 
@@ -254,39 +425,56 @@ func resourceBigipLtmVirtualServerUpdate(d *schema.ResourceData, meta interface{
 	var profiles []bigip.Profile
 	if p, ok := d.GetOk("profiles"); ok {
 		for _, profile := range p.(*schema.Set).List() {
-			profiles = append(profiles, bigip.Profile{Name: profile.(string), Context: bigip.CONTEXT_ALL})
+			profiles = append(profiles, bigip.Profile{Name: fullPath(client.Partition, profile.(string)), Context: bigip.CONTEXT_ALL})
 		}
 	}
 	if p, ok := d.GetOk("client_profiles"); ok {
 		for _, profile := range p.(*schema.Set).List() {
-			profiles = append(profiles, bigip.Profile{Name: profile.(string), Context: bigip.CONTEXT_CLIENT})
+			profiles = append(profiles, bigip.Profile{Name: fullPath(client.Partition, profile.(string)), Context: bigip.CONTEXT_CLIENT})
 		}
 	}
 	if p, ok := d.GetOk("server_profiles"); ok {
 		for _, profile := range p.(*schema.Set).List() {
-			profiles = append(profiles, bigip.Profile{Name: profile.(string), Context: bigip.CONTEXT_SERVER})
+			profiles = append(profiles, bigip.Profile{Name: fullPath(client.Partition, profile.(string)), Context: bigip.CONTEXT_SERVER})
 		}
 	}
 
 	var policies []string
 	if p, ok := d.GetOk("policies"); ok {
-		policies = setToStringSlice(p.(*schema.Set))
+		policies = fullPaths(client.Partition, setToStringSlice(p.(*schema.Set)))
 	}
 
-	var vlans []string
-	if v, ok := d.GetOk("vlans"); ok {
-		vlans = setToStringSlice(v.(*schema.Set))
+	vlans := fullPaths(client.Partition, setToStringSlice(d.Get("vlans").(*schema.Set)))
+
+	// BIG-IP runs iRule events in the order the rules are listed
+	rules := fullPaths(client.Partition, listToStringSlice(d.Get("irules").([]interface{})))
+
+	// The first persistence profile is the primary one
+	persistence := []bigip.VirtualServerPersistence{}
+	if p, ok := d.GetOk("persistence_profiles"); ok {
+		for i, name := range fullPaths(client.Partition, listToStringSlice(p.([]interface{}))) {
+			persistence = append(persistence, bigip.VirtualServerPersistence{
+				Name:    name,
+				Primary: strconv.FormatBool(i == 0),
+			})
+		}
+	}
+	fallbackPersistence := fullPath(client.Partition, d.Get("fallback_persistence_profile").(string))
+	if fallbackPersistence == "" {
+		fallbackPersistence = "none"
 	}
 
-	var rules []string
-	if cfg_rules, ok := d.GetOk("irules"); ok {
-		rules = setToStringSlice(cfg_rules.(*schema.Set))
+	rateLimit := "disabled"
+	if r := d.Get("rate_limit").(int); r > 0 {
+		rateLimit = strconv.Itoa(r)
 	}
 
+	toggle := map[bool]string{true: "enabled", false: "disabled"}
+
 	vs := &bigip.VirtualServer{
-		Destination: fmt.Sprintf("%s:%d", d.Get("destination").(string), d.Get("port").(int)),
+		Destination: bigip.FormatDestination("", d.Get("destination").(string), d.Get("port").(int)),
 		Source:      d.Get("source").(string),
-		Pool:        d.Get("pool").(string),
+		Pool:        fullPath(client.Partition, d.Get("pool").(string)),
 		Mask:        d.Get("mask").(string),
 		Rules:       rules,
 		Profiles:    profiles,
@@ -298,8 +486,27 @@ func resourceBigipLtmVirtualServerUpdate(d *schema.ResourceData, meta interface{
 			Pool string `json:"pool,omitempty"`
 		}{
 			Type: d.Get("source_address_translation").(string),
-			Pool: d.Get("snatpool").(string),
+			Pool: fullPath(client.Partition, d.Get("snatpool").(string)),
 		},
+		Description:              d.Get("description").(string),
+		Enabled:                  d.Get("enabled").(bool),
+		Disabled:                 !d.Get("enabled").(bool),
+		SourcePort:               d.Get("source_port").(string),
+		ConnectionLimit:          d.Get("connection_limit").(int),
+		RateLimit:                rateLimit,
+		RateLimitMode:            d.Get("rate_limit_mode").(string),
+		RateLimitSourceMask:      d.Get("rate_limit_source_mask").(int),
+		RateLimitDestinationMask: d.Get("rate_limit_destination_mask").(int),
+		TranslateAddress:         toggle[d.Get("translate_address").(bool)],
+		TranslatePort:            toggle[d.Get("translate_port").(bool)],
+		Mirror:                   toggle[d.Get("mirror").(bool)],
+		AutoLastHop:              d.Get("auto_lasthop").(string),
+		NAT64:                    toggle[d.Get("nat64").(bool)],
+		GTMScore:                 d.Get("gtm_score").(int),
+		VlansEnabled:             d.Get("vlans_enabled").(bool),
+		VlansDisabled:            !d.Get("vlans_enabled").(bool),
+		Persist:                  persistence,
+		FallbackPersistence:      fallbackPersistence,
 	}
 
 	err := client.ModifyVirtualServer(name, vs)
@@ -320,5 +527,7 @@ func resourceBigipLtmVirtualServerDelete(d *schema.ResourceData, meta interface{
 }
 
 func resourceBigipLtmVirtualServerImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
 	return []*schema.ResourceData{d}, nil
 }
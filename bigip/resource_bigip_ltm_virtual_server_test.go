@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/scottdware/go-bigip"
+	"strings"
 	"testing"
 )
 
@@ -29,7 +30,7 @@ resource "bigip_ltm_virtual_server" "test-vs" {
 `
 
 func TestBigipLtmVS_create(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAcctPreCheck(t)
 		},
@@ -50,9 +51,7 @@ func TestBigipLtmVS_create(t *testing.T) {
 					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "mask", "255.255.255.255"),
 					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "source_address_translation", "automap"),
 					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "ip_protocol", "tcp"),
-					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs",
-						fmt.Sprintf("irules.%d", schema.HashString(TEST_IRULE_NAME)),
-						TEST_IRULE_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "irules.0", TEST_IRULE_NAME),
 					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs",
 						fmt.Sprintf("profiles.%d", schema.HashString("/Common/http")),
 						"/Common/http"),
@@ -67,7 +66,285 @@ func TestBigipLtmVS_create(t *testing.T) {
 						TEST_POLICY_NAME),
 					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs",
 						fmt.Sprintf("vlans.%d", schema.HashString("TEST_VLAN_NAME")),
-					  "TEST_VLAN_NAME"),
+						"TEST_VLAN_NAME"),
+				),
+			},
+		},
+	})
+}
+
+var TEST_VS_MINIMAL_RESOURCE = `
+resource "bigip_ltm_virtual_server" "test-vs" {
+	name = "` + TEST_VS_NAME + `"
+	destination = "10.255.255.254"
+	port = 9999
+}
+`
+
+var TEST_VS_ATTRIBUTES_RESOURCE = `
+resource "bigip_ltm_virtual_server" "test-vs" {
+	name = "` + TEST_VS_NAME + `"
+	destination = "10.255.255.254"
+	port = 9999
+	description = "test virtual server"
+	enabled = false
+	vlans = ["/Common/external"]
+	vlans_enabled = true
+	source_port = "preserve-strict"
+	connection_limit = 1000
+	rate_limit = 500
+	rate_limit_mode = "source"
+	rate_limit_source_mask = 24
+	rate_limit_destination_mask = 32
+	translate_address = false
+	translate_port = false
+	mirror = true
+	auto_lasthop = "disabled"
+	nat64 = true
+	gtm_score = 10
+	persistence_profiles = ["/Common/cookie"]
+	fallback_persistence_profile = "/Common/source_addr"
+}
+`
+
+func TestBigipLtmVS_attributes(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckVSsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_VS_MINIMAL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVSExists(TEST_VS_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "enabled", "true"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "vlans_enabled", "false"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "source_port", "preserve"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "rate_limit", "0"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "translate_address", "true"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "translate_port", "true"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "syn_cookie_status", "not-activated"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "persistence_profiles.#", "0"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "fallback_persistence_profile", ""),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_VS_ATTRIBUTES_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVSExists(TEST_VS_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "port", "9999"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "description", "test virtual server"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "enabled", "false"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "vlans_enabled", "true"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "source_port", "preserve-strict"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "connection_limit", "1000"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "rate_limit", "500"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "rate_limit_mode", "source"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "rate_limit_source_mask", "24"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "rate_limit_destination_mask", "32"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "translate_address", "false"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "translate_port", "false"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "mirror", "true"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "auto_lasthop", "disabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "nat64", "true"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "gtm_score", "10"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "persistence_profiles.0", "/Common/cookie"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "fallback_persistence_profile", "/Common/source_addr"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_VS_MINIMAL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVSExists(TEST_VS_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "description", ""),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "enabled", "true"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "vlans_enabled", "false"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "rate_limit", "0"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "mirror", "false"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "persistence_profiles.#", "0"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "fallback_persistence_profile", ""),
+				),
+			},
+		},
+	})
+}
+
+var TEST_VS_ROUTE_DOMAIN_RESOURCE = TEST_ROUTE_DOMAIN_RESOURCE + `
+resource "bigip_ltm_virtual_server" "test-vs" {
+	name = "` + TEST_VS_NAME + `"
+	destination = "10.255.255.254%${bigip_net_route_domain.test-route-domain.route_domain_id}"
+	source = "0.0.0.0%${bigip_net_route_domain.test-route-domain.route_domain_id}/0"
+	port = 9999
+}
+`
+
+func TestBigipLtmVS_routeDomain(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckVSsDestroyed,
+			testCheckRouteDomainsDestroyed,
+			testCheckVlansDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_VS_ROUTE_DOMAIN_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVSExists(TEST_VS_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "destination", "10.255.255.254%42"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "source", "0.0.0.0%42/0"),
+				),
+			},
+		},
+	})
+}
+
+var TEST_IRULE2_NAME = "/" + TEST_PARTITION + "/test-rule2"
+
+var TEST_VS_IRULES_RESOURCE = TEST_IRULE_RESOURCE + `
+resource "bigip_ltm_irule" "test-rule2" {
+	name = "` + TEST_IRULE2_NAME + `"
+	irule = "when HTTP_REQUEST { log local0. \"test2\" }"
+}
+
+resource "bigip_ltm_virtual_server" "test-vs" {
+	name = "` + TEST_VS_NAME + `"
+	destination = "10.255.255.254"
+	port = 9999
+	irules = ["${bigip_ltm_irule.test-rule.name}", "${bigip_ltm_irule.test-rule2.name}"]
+}
+`
+
+var TEST_VS_IRULES_REORDERED_RESOURCE = TEST_IRULE_RESOURCE + `
+resource "bigip_ltm_irule" "test-rule2" {
+	name = "` + TEST_IRULE2_NAME + `"
+	irule = "when HTTP_REQUEST { log local0. \"test2\" }"
+}
+
+resource "bigip_ltm_virtual_server" "test-vs" {
+	name = "` + TEST_VS_NAME + `"
+	destination = "10.255.255.254"
+	port = 9999
+	irules = ["${bigip_ltm_irule.test-rule2.name}", "${bigip_ltm_irule.test-rule.name}"]
+}
+`
+
+func TestBigipLtmVS_iruleOrder(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckVSsDestroyed,
+			testCheckIRulesDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_VS_IRULES_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVSRules(TEST_VS_NAME, []string{TEST_IRULE_NAME, TEST_IRULE2_NAME}),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "irules.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "irules.0", TEST_IRULE_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "irules.1", TEST_IRULE2_NAME),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_VS_IRULES_REORDERED_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVSRules(TEST_VS_NAME, []string{TEST_IRULE2_NAME, TEST_IRULE_NAME}),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "irules.0", TEST_IRULE2_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "irules.1", TEST_IRULE_NAME),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_IRULE_RESOURCE + TEST_VS_MINIMAL_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVSRules(TEST_VS_NAME, []string{}),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "irules.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+var TEST_VS_PERSISTENCE_ORDER_RESOURCE = `
+resource "bigip_ltm_virtual_server" "test-vs" {
+	name = "` + TEST_VS_NAME + `"
+	destination = "10.255.255.254"
+	port = 9999
+	persistence_profiles = ["/Common/source_addr", "/Common/cookie"]
+}
+`
+
+var TEST_VS_PERSISTENCE_REORDERED_RESOURCE = `
+resource "bigip_ltm_virtual_server" "test-vs" {
+	name = "` + TEST_VS_NAME + `"
+	destination = "10.255.255.254"
+	port = 9999
+	persistence_profiles = ["/Common/cookie", "/Common/source_addr"]
+}
+`
+
+func TestBigipLtmVS_persistenceOrder(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckVSsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_VS_PERSISTENCE_ORDER_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVSPrimaryPersistence(TEST_VS_NAME, "/Common/source_addr"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "persistence_profiles.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "persistence_profiles.0", "/Common/source_addr"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "persistence_profiles.1", "/Common/cookie"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_VS_PERSISTENCE_REORDERED_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVSPrimaryPersistence(TEST_VS_NAME, "/Common/cookie"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "persistence_profiles.0", "/Common/cookie"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "persistence_profiles.1", "/Common/source_addr"),
+				),
+			},
+		},
+	})
+}
+
+var TEST_VS_IPV6_RESOURCE = `
+resource "bigip_ltm_virtual_server" "test-vs" {
+	name = "` + TEST_VS_NAME + `"
+	destination = "2001:db8::1"
+	port = 443
+	source = "::/0"
+	mask = "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff"
+}
+`
+
+func TestBigipLtmVS_ipv6(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckVSsDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_VS_IPV6_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVSExists(TEST_VS_NAME, true),
+					testCheckVSDestination(TEST_VS_NAME, "/Common/2001:db8::1.443"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "destination", "2001:db8::1"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "port", "443"),
 				),
 			},
 		},
@@ -75,7 +352,7 @@ func TestBigipLtmVS_create(t *testing.T) {
 }
 
 func TestBigipLtmVS_import(t *testing.T) {
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck: func() {
 			testAcctPreCheck(t)
 		},
@@ -95,6 +372,68 @@ func TestBigipLtmVS_import(t *testing.T) {
 	})
 }
 
+var TEST_VS_PARTITION_RESOURCE = `
+provider "bigip" {
+	partition = "tenant"
+}
+
+resource "bigip_ltm_pool" "tenant-pool" {
+	name = "tenant-pool"
+}
+
+resource "bigip_ltm_virtual_server" "tenant-vs" {
+	name = "tenant-vs"
+	destination = "10.255.255.252"
+	port = 80
+	pool = "${bigip_ltm_pool.tenant-pool.name}"
+	profiles = ["/Common/http"]
+	persistence_profiles = ["/Common/cookie"]
+}
+`
+
+func TestBigipLtmVS_partition(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckVSsDestroyed,
+			testCheckPoolsDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_VS_PARTITION_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVSExists("/tenant/tenant-vs", true),
+					testCheckPoolExists("/tenant/tenant-pool", true),
+					testCheckVSPool("/tenant/tenant-vs", "/tenant/tenant-pool"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.tenant-vs", "id", "/tenant/tenant-vs"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.tenant-vs", "name", "/tenant/tenant-vs"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.tenant-vs", "pool", "/tenant/tenant-pool"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.tenant-vs",
+						fmt.Sprintf("profiles.%d", schema.HashString("/Common/http")),
+						"/Common/http"),
+				),
+			},
+			resource.TestStep{
+				Config:            TEST_VS_PARTITION_RESOURCE,
+				ResourceName:      "bigip_ltm_virtual_server.tenant-vs",
+				ImportState:       true,
+				ImportStateId:     "tenant-vs",
+				ImportStateVerify: true,
+			},
+			resource.TestStep{
+				Config: strings.Replace(TEST_VS_PARTITION_RESOURCE, `name = "tenant-`, `name = "/tenant/tenant-`, -1),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVSExists("/tenant/tenant-vs", true),
+					testCheckVSPool("/tenant/tenant-vs", "/tenant/tenant-pool"),
+				),
+			},
+		},
+	})
+}
+
 //TODO: test adding rules, profiles, policies, etc
 
 func testCheckVSExists(name string, exists bool) resource.TestCheckFunc {
@@ -106,20 +445,102 @@ func testCheckVSExists(name string, exists bool) resource.TestCheckFunc {
 			return err
 		}
 		if exists && vs == nil {
-			return fmt.Errorf("Virtual server ", name, " does not exist.")
+			return fmt.Errorf("Virtual server %s does not exist.", name)
 		}
 		if !exists && vs != nil {
-			return fmt.Errorf("Virtual server ", name, " exists.")
+			return fmt.Errorf("Virtual server %s exists.", name)
 		}
 		return nil
 	}
 }
 
+func testCheckVSPool(name, pool string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		vs, err := client.GetVirtualServer(name)
+		if err != nil {
+			return err
+		}
+		if vs == nil {
+			return fmt.Errorf("Virtual server %s does not exist.", name)
+		}
+		if vs.Pool != pool {
+			return fmt.Errorf("Virtual server %s pool is %s, expected %s", name, vs.Pool, pool)
+		}
+		return nil
+	}
+}
+
+func testCheckVSDestination(name, destination string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		vs, err := client.GetVirtualServer(name)
+		if err != nil {
+			return err
+		}
+		if vs == nil {
+			return fmt.Errorf("Virtual server %s does not exist.", name)
+		}
+		if vs.Destination != destination {
+			return fmt.Errorf("Virtual server %s destination is %s, expected %s", name, vs.Destination, destination)
+		}
+		return nil
+	}
+}
+
+func testCheckVSRules(name string, rules []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		vs, err := client.GetVirtualServer(name)
+		if err != nil {
+			return err
+		}
+		if vs == nil {
+			return fmt.Errorf("Virtual server %s does not exist.", name)
+		}
+		if len(vs.Rules) != len(rules) {
+			return fmt.Errorf("Virtual server %s has rules %v, expected %v", name, vs.Rules, rules)
+		}
+		for i, rule := range rules {
+			if vs.Rules[i] != rule {
+				return fmt.Errorf("Virtual server %s has rules %v, expected %v", name, vs.Rules, rules)
+			}
+		}
+		return nil
+	}
+}
+
+func testCheckVSPrimaryPersistence(name, primary string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		vs, err := client.GetVirtualServer(name)
+		if err != nil {
+			return err
+		}
+		if vs == nil {
+			return fmt.Errorf("Virtual server %s does not exist.", name)
+		}
+		for _, p := range vs.Persist {
+			if p.Primary == "true" {
+				if path := fullPath(p.Partition, p.Name); path != primary {
+					return fmt.Errorf("Virtual server %s primary persistence profile is %s, expected %s", name, path, primary)
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("Virtual server %s has no primary persistence profile", name)
+	}
+}
+
 func testCheckVSsDestroyed(s *terraform.State) error {
 	client := testAccProvider.Meta().(*bigip.BigIP)
 
 	for _, rs := range s.RootModule().Resources {
-		if rs.Type != "bigip_ltm_virtual_address" {
+		if rs.Type != "bigip_ltm_virtual_server" {
 			continue
 		}
 
@@ -129,7 +550,7 @@ func testCheckVSsDestroyed(s *terraform.State) error {
 			return err
 		}
 		if vs != nil {
-			return fmt.Errorf("Virtual server ", name, " not destroyed.")
+			return fmt.Errorf("Virtual server %s not destroyed.", name)
 		}
 	}
 	return nil
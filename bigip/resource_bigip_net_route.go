@@ -0,0 +1,161 @@
+package bigip
+
+import (
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipNetRoute() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipNetRouteCreate,
+		Read:   resourceBigipNetRouteRead,
+		Update: resourceBigipNetRouteUpdate,
+		Delete: resourceBigipNetRouteDelete,
+		Exists: resourceBigipNetRouteExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceBigipNetRouteImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the route",
+				ForceNew:     true,
+				ValidateFunc: validateF5Name,
+			},
+
+			"network": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Destination network in CIDR notation, or default. May include a route domain, e.g. 10.2.0.0%2/16",
+				ForceNew:     true,
+				ValidateFunc: validateRouteNetwork,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return routeNetwork(old) == routeNetwork(new)
+				},
+			},
+
+			"gateway": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Gateway address. May include a route domain, e.g. 10.1.1.254%2",
+				ValidateFunc: validateIPAddress,
+			},
+
+			"mtu": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Maximum transmission unit for the route. The egress interface MTU is used if not specified",
+				ValidateFunc: validateIntRange(0, 9198),
+			},
+		},
+	}
+}
+
+func resourceBigipNetRouteCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := fullPath(client.Partition, d.Get("name").(string))
+
+	log.Println("[INFO] Creating route " + name)
+	d.SetId(name)
+	err := client.Transaction(func(tx *bigip.BigIP) error {
+		err := tx.CreateRoute(name, d.Get("network").(string), d.Get("gateway").(string))
+		if err != nil {
+			return err
+		}
+		return resourceBigipNetRouteUpdate(d, tx)
+	})
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceBigipNetRouteRead(d, meta)
+}
+
+func resourceBigipNetRouteRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	log.Println("[INFO] Reading route " + name)
+
+	route, err := client.GetRoute(name)
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", name)
+	d.Set("network", route.Network)
+	d.Set("gateway", route.Gateway)
+	d.Set("mtu", route.MTU)
+
+	return nil
+}
+
+func resourceBigipNetRouteExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Checking route " + name + " exists.")
+
+	route, err := client.GetRoute(name)
+	if err != nil {
+		return false, err
+	}
+
+	if route == nil {
+		d.SetId("")
+	}
+
+	return route != nil, nil
+}
+
+func resourceBigipNetRouteUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	route := &bigip.Route{
+		Gateway: d.Get("gateway").(string),
+		MTU:     d.Get("mtu").(int),
+	}
+
+	return client.ModifyRoute(name, route)
+}
+
+func resourceBigipNetRouteDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting route " + name)
+
+	return client.DeleteRoute(name)
+}
+
+// BIG-IP reports the all-addresses networks 0.0.0.0/0 and ::/0 as default and
+// default-inet6, keeping any route domain, e.g. 0.0.0.0%2/0 is default%2
+var defaultRouteNetwork = regexp.MustCompile("^(0\\.0\\.0\\.0|::)(%\\d+)?/0$")
+
+func routeNetwork(network string) string {
+	match := defaultRouteNetwork.FindStringSubmatch(network)
+	if match == nil {
+		return network
+	}
+	if match[1] == "::" {
+		return "default-inet6" + match[2]
+	}
+	return "default" + match[2]
+}
+
+func resourceBigipNetRouteImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
+	return []*schema.ResourceData{d}, nil
+}
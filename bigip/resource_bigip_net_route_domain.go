@@ -0,0 +1,155 @@
+package bigip
+
+import (
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipNetRouteDomain() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipNetRouteDomainCreate,
+		Read:   resourceBigipNetRouteDomainRead,
+		Update: resourceBigipNetRouteDomainUpdate,
+		Delete: resourceBigipNetRouteDomainDelete,
+		Exists: resourceBigipNetRouteDomainExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceBigipNetRouteDomainImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the route domain",
+				ForceNew:     true,
+				ValidateFunc: validateF5Name,
+			},
+
+			"route_domain_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				Description:  "Numeric ID of the route domain, used as the %ID suffix on addresses",
+				ForceNew:     true,
+				ValidateFunc: validateIntRange(0, 65534),
+			},
+
+			"strict": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Strict isolation: prevent routes from crossing into other route domains",
+			},
+
+			"vlans": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+				Description: "VLANs assigned to the route domain",
+			},
+		},
+	}
+}
+
+func resourceBigipNetRouteDomainCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := fullPath(client.Partition, d.Get("name").(string))
+	vlans := fullPaths(client.Partition, setToStringSlice(d.Get("vlans").(*schema.Set)))
+
+	log.Println("[INFO] Creating route domain " + name)
+	d.SetId(name)
+	err := client.Transaction(func(tx *bigip.BigIP) error {
+		err := tx.CreateRouteDomain(
+			name,
+			d.Get("route_domain_id").(int),
+			d.Get("strict").(bool),
+			strings.Join(vlans, ","),
+		)
+		if err != nil {
+			return err
+		}
+		return resourceBigipNetRouteDomainUpdate(d, tx)
+	})
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceBigipNetRouteDomainRead(d, meta)
+}
+
+func resourceBigipNetRouteDomainRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	log.Println("[INFO] Reading route domain " + name)
+
+	rd, err := client.GetRouteDomain(name)
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", name)
+	d.Set("route_domain_id", rd.ID)
+	d.Set("strict", rd.Strict == "enabled")
+	vlans := configuredPaths(client.Partition, setToStringSlice(d.Get("vlans").(*schema.Set)), rd.Vlans)
+	d.Set("vlans", makeStringSet(&vlans))
+
+	return nil
+}
+
+func resourceBigipNetRouteDomainExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Checking route domain " + name + " exists.")
+
+	rd, err := client.GetRouteDomain(name)
+	if err != nil {
+		return false, err
+	}
+
+	if rd == nil {
+		d.SetId("")
+	}
+
+	return rd != nil, nil
+}
+
+func resourceBigipNetRouteDomainUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	strict := "disabled"
+	if d.Get("strict").(bool) {
+		strict = "enabled"
+	}
+
+	rd := &bigip.RouteDomain{
+		Strict: strict,
+		Vlans:  fullPaths(client.Partition, setToStringSlice(d.Get("vlans").(*schema.Set))),
+	}
+
+	return client.ModifyRouteDomain(name, rd)
+}
+
+func resourceBigipNetRouteDomainDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting route domain " + name)
+
+	return client.DeleteRouteDomain(name)
+}
+
+func resourceBigipNetRouteDomainImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,129 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/scottdware/go-bigip"
+)
+
+var TEST_ROUTE_DOMAIN_NAME = fmt.Sprintf("/%s/test-route-domain", TEST_PARTITION)
+
+var TEST_ROUTE_DOMAIN_RESOURCE = TEST_VLAN_RESOURCE + `
+resource "bigip_net_route_domain" "test-route-domain" {
+	name = "` + TEST_ROUTE_DOMAIN_NAME + `"
+	route_domain_id = 42
+	vlans = ["${bigip_net_vlan.test-vlan.name}"]
+}
+`
+
+var TEST_ROUTE_DOMAIN_RESOURCE_UPDATED = TEST_VLAN_RESOURCE + `
+resource "bigip_net_route_domain" "test-route-domain" {
+	name = "` + TEST_ROUTE_DOMAIN_NAME + `"
+	route_domain_id = 42
+	strict = false
+}
+`
+
+func TestBigipNetRouteDomain_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckRouteDomainsDestroyed,
+			testCheckVlansDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_ROUTE_DOMAIN_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRouteDomainExists(TEST_ROUTE_DOMAIN_NAME, true),
+					resource.TestCheckResourceAttr("bigip_net_route_domain.test-route-domain", "name", TEST_ROUTE_DOMAIN_NAME),
+					resource.TestCheckResourceAttr("bigip_net_route_domain.test-route-domain", "route_domain_id", "42"),
+					resource.TestCheckResourceAttr("bigip_net_route_domain.test-route-domain", "strict", "true"),
+					resource.TestCheckResourceAttr("bigip_net_route_domain.test-route-domain", "vlans.#", "1"),
+					resource.TestCheckResourceAttr("bigip_net_route_domain.test-route-domain",
+						fmt.Sprintf("vlans.%d", schema.HashString(TEST_VLAN_NAME)),
+						TEST_VLAN_NAME),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_ROUTE_DOMAIN_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRouteDomainExists(TEST_ROUTE_DOMAIN_NAME, true),
+					resource.TestCheckResourceAttr("bigip_net_route_domain.test-route-domain", "strict", "false"),
+					resource.TestCheckResourceAttr("bigip_net_route_domain.test-route-domain", "vlans.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipNetRouteDomain_import(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckRouteDomainsDestroyed,
+			testCheckVlansDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_ROUTE_DOMAIN_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRouteDomainExists(TEST_ROUTE_DOMAIN_NAME, true),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_net_route_domain.test-route-domain",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckRouteDomainExists(name string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		rd, err := client.GetRouteDomain(name)
+		if err != nil {
+			return err
+		}
+		if exists && rd == nil {
+			return fmt.Errorf("Route domain %s does not exist.", name)
+		}
+		if !exists && rd != nil {
+			return fmt.Errorf("Route domain %s exists.", name)
+		}
+		return nil
+	}
+}
+
+func testCheckRouteDomainsDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_net_route_domain" {
+			continue
+		}
+
+		name := rs.Primary.ID
+		rd, err := client.GetRouteDomain(name)
+		if err != nil {
+			return err
+		}
+		if rd != nil {
+			return fmt.Errorf("Route domain %s not destroyed.", name)
+		}
+	}
+	return nil
+}
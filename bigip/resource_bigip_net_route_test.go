@@ -0,0 +1,171 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/scottdware/go-bigip"
+	"github.com/stretchr/testify/assert"
+)
+
+var TEST_ROUTE_NAME = fmt.Sprintf("/%s/test-route", TEST_PARTITION)
+
+var TEST_ROUTE_RESOURCE = TEST_ROUTE_DOMAIN_RESOURCE + `
+resource "bigip_net_route" "test-route" {
+	name = "` + TEST_ROUTE_NAME + `"
+	network = "10.2.0.0%${bigip_net_route_domain.test-route-domain.route_domain_id}/16"
+	gateway = "10.1.1.254%${bigip_net_route_domain.test-route-domain.route_domain_id}"
+}
+`
+
+var TEST_ROUTE_RESOURCE_UPDATED = TEST_ROUTE_DOMAIN_RESOURCE + `
+resource "bigip_net_route" "test-route" {
+	name = "` + TEST_ROUTE_NAME + `"
+	network = "10.2.0.0%${bigip_net_route_domain.test-route-domain.route_domain_id}/16"
+	gateway = "10.1.1.253%${bigip_net_route_domain.test-route-domain.route_domain_id}"
+	mtu = 1400
+}
+`
+
+func TestBigipNetRoute_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckRoutesDestroyed,
+			testCheckRouteDomainsDestroyed,
+			testCheckVlansDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_ROUTE_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRouteExists(TEST_ROUTE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_net_route.test-route", "name", TEST_ROUTE_NAME),
+					resource.TestCheckResourceAttr("bigip_net_route.test-route", "network", "10.2.0.0%42/16"),
+					resource.TestCheckResourceAttr("bigip_net_route.test-route", "gateway", "10.1.1.254%42"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_ROUTE_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRouteExists(TEST_ROUTE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_net_route.test-route", "gateway", "10.1.1.253%42"),
+					resource.TestCheckResourceAttr("bigip_net_route.test-route", "mtu", "1400"),
+				),
+			},
+		},
+	})
+}
+
+var TEST_ROUTE_RESOURCE_DEFAULT = `
+resource "bigip_net_route" "test-route" {
+	name = "` + TEST_ROUTE_NAME + `"
+	network = "0.0.0.0/0"
+	gateway = "10.1.1.254"
+}
+`
+
+func TestBigipNetRoute_default(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckRoutesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_ROUTE_RESOURCE_DEFAULT,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRouteExists(TEST_ROUTE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_net_route.test-route", "network", "default"),
+				),
+			},
+		},
+	})
+}
+
+func TestDefaultRouteNetwork(t *testing.T) {
+	data := map[string]string{
+		"0.0.0.0/0":     "default",
+		"0.0.0.0%2/0":   "default%2",
+		"::/0":          "default-inet6",
+		"::%2/0":        "default-inet6%2",
+		"default":       "default",
+		"10.0.0.0/8":    "10.0.0.0/8",
+		"0.0.0.0/8":     "0.0.0.0/8",
+		"10.2.0.0%2/16": "10.2.0.0%2/16",
+	}
+	for network, expected := range data {
+		assert.Equal(t, expected, routeNetwork(network), network)
+	}
+}
+
+func TestBigipNetRoute_import(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckRoutesDestroyed,
+			testCheckRouteDomainsDestroyed,
+			testCheckVlansDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_ROUTE_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckRouteExists(TEST_ROUTE_NAME, true),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_net_route.test-route",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckRouteExists(name string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		route, err := client.GetRoute(name)
+		if err != nil {
+			return err
+		}
+		if exists && route == nil {
+			return fmt.Errorf("Route %s does not exist.", name)
+		}
+		if !exists && route != nil {
+			return fmt.Errorf("Route %s exists.", name)
+		}
+		return nil
+	}
+}
+
+func testCheckRoutesDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_net_route" {
+			continue
+		}
+
+		name := rs.Primary.ID
+		route, err := client.GetRoute(name)
+		if err != nil {
+			return err
+		}
+		if route != nil {
+			return fmt.Errorf("Route %s not destroyed.", name)
+		}
+	}
+	return nil
+}
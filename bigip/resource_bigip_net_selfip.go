@@ -0,0 +1,188 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipNetSelfIP() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipNetSelfIPCreate,
+		Read:   resourceBigipNetSelfIPRead,
+		Update: resourceBigipNetSelfIPUpdate,
+		Delete: resourceBigipNetSelfIPDelete,
+		Exists: resourceBigipNetSelfIPExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceBigipNetSelfIPImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the self IP",
+				ForceNew:     true,
+				ValidateFunc: validateF5Name,
+			},
+
+			"ip": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Address and netmask in CIDR notation, e.g. 10.1.1.1/24",
+				ValidateFunc: validateCIDR,
+			},
+
+			"vlan": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "VLAN the self IP is on",
+				ForceNew:     true,
+				ValidateFunc: validateF5Name,
+			},
+
+			"traffic_group": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "/Common/traffic-group-local-only",
+				Description:  "Traffic group. traffic-group-local-only makes a non-floating self IP",
+				ValidateFunc: validateF5FullPath,
+			},
+
+			"floating": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the self IP floats between devices with its traffic group",
+			},
+
+			"port_lockdown": &schema.Schema{
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validatePortLockdown,
+				},
+				Set:         schema.HashString,
+				Optional:    true,
+				Description: "Services allowed on the self IP: all, default, or protocol:port entries. Empty allows none.",
+			},
+		},
+	}
+}
+
+func resourceBigipNetSelfIPCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := fullPath(client.Partition, d.Get("name").(string))
+
+	log.Println("[INFO] Creating self IP " + name)
+	d.SetId(name)
+	err := client.Transaction(func(tx *bigip.BigIP) error {
+		err := tx.CreateSelfIP(name, d.Get("ip").(string), fullPath(client.Partition, d.Get("vlan").(string)))
+		if err != nil {
+			return err
+		}
+		return modifySelfIP(tx, d)
+	})
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceBigipNetSelfIPRead(d, meta)
+}
+
+func resourceBigipNetSelfIPRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	log.Println("[INFO] Reading self IP " + name)
+
+	self, err := client.GetSelfIP(name)
+	if err != nil {
+		return err
+	}
+
+	services := self.AllowService
+	if len(services) == 1 && services[0] == "none" {
+		services = nil
+	}
+
+	d.Set("name", name)
+	d.Set("ip", self.Address)
+	d.Set("vlan", configuredPath(client.Partition, d.Get("vlan").(string), self.Vlan))
+	d.Set("traffic_group", self.TrafficGroup)
+	d.Set("floating", self.Floating == "enabled")
+	d.Set("port_lockdown", makeStringSet(&services))
+
+	return nil
+}
+
+func resourceBigipNetSelfIPExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Checking self IP " + name + " exists.")
+
+	self, err := client.GetSelfIP(name)
+	if err != nil {
+		return false, err
+	}
+
+	if self == nil {
+		d.SetId("")
+	}
+
+	return self != nil, nil
+}
+
+func resourceBigipNetSelfIPUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	err := modifySelfIP(client, d)
+	if err != nil {
+		return err
+	}
+
+	// floating follows the traffic group, so pick up the new value
+	return resourceBigipNetSelfIPRead(d, meta)
+}
+
+func modifySelfIP(client *bigip.BigIP, d *schema.ResourceData) error {
+	services := setToStringSlice(d.Get("port_lockdown").(*schema.Set))
+	if len(services) == 0 {
+		services = []string{"none"}
+	}
+	if len(services) > 1 {
+		for _, service := range services {
+			if service == "all" || service == "default" {
+				return fmt.Errorf("port_lockdown %s can't be combined with other entries", service)
+			}
+		}
+	}
+
+	self := &bigip.SelfIP{
+		Address:      d.Get("ip").(string),
+		TrafficGroup: d.Get("traffic_group").(string),
+		AllowService: services,
+	}
+
+	return client.ModifySelfIP(d.Id(), self)
+}
+
+func resourceBigipNetSelfIPDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting self IP " + name)
+
+	return client.DeleteSelfIP(name)
+}
+
+func resourceBigipNetSelfIPImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,145 @@
+package bigip
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/scottdware/go-bigip"
+)
+
+var TEST_SELFIP_NAME = fmt.Sprintf("/%s/test-selfip", TEST_PARTITION)
+
+var TEST_SELFIP_RESOURCE = TEST_VLAN_RESOURCE + `
+resource "bigip_net_selfip" "test-selfip" {
+	name = "` + TEST_SELFIP_NAME + `"
+	ip = "10.10.10.1/24"
+	vlan = "${bigip_net_vlan.test-vlan.name}"
+	port_lockdown = ["tcp:22", "tcp:443"]
+}
+`
+
+var TEST_SELFIP_RESOURCE_FLOATING = TEST_VLAN_RESOURCE + `
+resource "bigip_net_selfip" "test-selfip" {
+	name = "` + TEST_SELFIP_NAME + `"
+	ip = "10.10.10.2/24"
+	vlan = "${bigip_net_vlan.test-vlan.name}"
+	traffic_group = "/Common/traffic-group-1"
+	port_lockdown = ["default"]
+}
+`
+
+func TestBigipNetSelfIP_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckSelfIPsDestroyed,
+			testCheckVlansDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_SELFIP_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSelfIPExists(TEST_SELFIP_NAME, true),
+					resource.TestCheckResourceAttr("bigip_net_selfip.test-selfip", "name", TEST_SELFIP_NAME),
+					resource.TestCheckResourceAttr("bigip_net_selfip.test-selfip", "ip", "10.10.10.1/24"),
+					resource.TestCheckResourceAttr("bigip_net_selfip.test-selfip", "vlan", TEST_VLAN_NAME),
+					resource.TestCheckResourceAttr("bigip_net_selfip.test-selfip", "traffic_group", "/Common/traffic-group-local-only"),
+					resource.TestCheckResourceAttr("bigip_net_selfip.test-selfip", "floating", "false"),
+					resource.TestCheckResourceAttr("bigip_net_selfip.test-selfip", "port_lockdown.#", "2"),
+					resource.TestCheckResourceAttr("bigip_net_selfip.test-selfip",
+						fmt.Sprintf("port_lockdown.%d", schema.HashString("tcp:443")),
+						"tcp:443"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_SELFIP_RESOURCE_FLOATING,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSelfIPExists(TEST_SELFIP_NAME, true),
+					resource.TestCheckResourceAttr("bigip_net_selfip.test-selfip", "ip", "10.10.10.2/24"),
+					resource.TestCheckResourceAttr("bigip_net_selfip.test-selfip", "traffic_group", "/Common/traffic-group-1"),
+					resource.TestCheckResourceAttr("bigip_net_selfip.test-selfip", "floating", "true"),
+					resource.TestCheckResourceAttr("bigip_net_selfip.test-selfip", "port_lockdown.#", "1"),
+					resource.TestCheckResourceAttr("bigip_net_selfip.test-selfip",
+						fmt.Sprintf("port_lockdown.%d", schema.HashString("default")),
+						"default"),
+				),
+			},
+			resource.TestStep{
+				Config:      strings.Replace(TEST_SELFIP_RESOURCE_FLOATING, `["default"]`, `["default", "tcp:22"]`, 1),
+				ExpectError: regexp.MustCompile("port_lockdown default can't be combined with other entries"),
+			},
+		},
+	})
+}
+
+func TestBigipNetSelfIP_import(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckSelfIPsDestroyed,
+			testCheckVlansDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_SELFIP_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSelfIPExists(TEST_SELFIP_NAME, true),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_net_selfip.test-selfip",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckSelfIPExists(name string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		self, err := client.GetSelfIP(name)
+		if err != nil {
+			return err
+		}
+		if exists && self == nil {
+			return fmt.Errorf("Self IP %s does not exist.", name)
+		}
+		if !exists && self != nil {
+			return fmt.Errorf("Self IP %s exists.", name)
+		}
+		return nil
+	}
+}
+
+func testCheckSelfIPsDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_net_selfip" {
+			continue
+		}
+
+		name := rs.Primary.ID
+		self, err := client.GetSelfIP(name)
+		if err != nil {
+			return err
+		}
+		if self != nil {
+			return fmt.Errorf("Self IP %s not destroyed.", name)
+		}
+	}
+	return nil
+}
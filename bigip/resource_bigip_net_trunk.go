@@ -0,0 +1,168 @@
+package bigip
+
+import (
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipNetTrunk() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipNetTrunkCreate,
+		Read:   resourceBigipNetTrunkRead,
+		Update: resourceBigipNetTrunkUpdate,
+		Delete: resourceBigipNetTrunkDelete,
+		Exists: resourceBigipNetTrunkExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceBigipNetTrunkImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the trunk. Trunks are not in a partition",
+				ForceNew:     true,
+				ValidateFunc: validateUnpartitionedName,
+			},
+
+			"interfaces": &schema.Schema{
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Required:    true,
+				Description: "Member interfaces, e.g. 1.1",
+			},
+
+			"lacp": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Enable Link Aggregation Control Protocol",
+			},
+
+			"lacp_mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "active",
+				Description:  "LACP mode: active or passive",
+				ValidateFunc: validateStringValue([]string{"active", "passive"}),
+			},
+
+			"lacp_timeout": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "long",
+				Description:  "LACP timeout: long or short",
+				ValidateFunc: validateStringValue([]string{"long", "short"}),
+			},
+
+			"distribution_hash": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "src-dst-ipport",
+				Description:  "Basis of the hash used to distribute frames across member interfaces",
+				ValidateFunc: validateStringValue([]string{"dst-mac", "src-dst-ipport", "src-dst-mac"}),
+			},
+		},
+	}
+}
+
+func resourceBigipNetTrunkCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Get("name").(string)
+	interfaces := setToStringSlice(d.Get("interfaces").(*schema.Set))
+
+	log.Println("[INFO] Creating trunk " + name)
+	d.SetId(name)
+	err := client.Transaction(func(tx *bigip.BigIP) error {
+		err := tx.CreateTrunk(name, strings.Join(interfaces, ","), d.Get("lacp").(bool))
+		if err != nil {
+			return err
+		}
+		return resourceBigipNetTrunkUpdate(d, tx)
+	})
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceBigipNetTrunkRead(d, meta)
+}
+
+func resourceBigipNetTrunkRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	log.Println("[INFO] Reading trunk " + name)
+
+	trunk, err := client.GetTrunk(name)
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", name)
+	d.Set("interfaces", makeStringSet(&trunk.Interfaces))
+	d.Set("lacp", trunk.LACP == "enabled")
+	d.Set("lacp_mode", trunk.LACPMode)
+	d.Set("lacp_timeout", trunk.LACPTimeout)
+	d.Set("distribution_hash", trunk.DistributionHash)
+
+	return nil
+}
+
+func resourceBigipNetTrunkExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Checking trunk " + name + " exists.")
+
+	trunk, err := client.GetTrunk(name)
+	if err != nil {
+		return false, err
+	}
+
+	if trunk == nil {
+		d.SetId("")
+	}
+
+	return trunk != nil, nil
+}
+
+func resourceBigipNetTrunkUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	lacp := "disabled"
+	if d.Get("lacp").(bool) {
+		lacp = "enabled"
+	}
+
+	trunk := &bigip.Trunk{
+		Interfaces:       setToStringSlice(d.Get("interfaces").(*schema.Set)),
+		LACP:             lacp,
+		LACPMode:         d.Get("lacp_mode").(string),
+		LACPTimeout:      d.Get("lacp_timeout").(string),
+		DistributionHash: d.Get("distribution_hash").(string),
+	}
+
+	return client.ModifyTrunk(name, trunk)
+}
+
+func resourceBigipNetTrunkDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting trunk " + name)
+
+	return client.DeleteTrunk(name)
+}
+
+func resourceBigipNetTrunkImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return []*schema.ResourceData{d}, nil
+}
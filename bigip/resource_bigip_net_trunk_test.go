@@ -0,0 +1,130 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/scottdware/go-bigip"
+)
+
+var TEST_TRUNK_NAME = "test-trunk"
+
+var TEST_TRUNK_RESOURCE = `
+resource "bigip_net_trunk" "test-trunk" {
+	name = "` + TEST_TRUNK_NAME + `"
+	interfaces = ["1.3", "1.4"]
+}
+`
+
+var TEST_TRUNK_RESOURCE_UPDATED = `
+resource "bigip_net_trunk" "test-trunk" {
+	name = "` + TEST_TRUNK_NAME + `"
+	interfaces = ["1.3", "1.4", "1.5"]
+	lacp = true
+	lacp_mode = "passive"
+	lacp_timeout = "short"
+	distribution_hash = "src-dst-mac"
+}
+`
+
+func TestBigipNetTrunk_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckTrunksDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_TRUNK_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckTrunkExists(TEST_TRUNK_NAME, true),
+					resource.TestCheckResourceAttr("bigip_net_trunk.test-trunk", "name", TEST_TRUNK_NAME),
+					resource.TestCheckResourceAttr("bigip_net_trunk.test-trunk", "interfaces.#", "2"),
+					resource.TestCheckResourceAttr("bigip_net_trunk.test-trunk",
+						fmt.Sprintf("interfaces.%d", schema.HashString("1.3")),
+						"1.3"),
+					resource.TestCheckResourceAttr("bigip_net_trunk.test-trunk", "lacp", "false"),
+					resource.TestCheckResourceAttr("bigip_net_trunk.test-trunk", "lacp_mode", "active"),
+					resource.TestCheckResourceAttr("bigip_net_trunk.test-trunk", "lacp_timeout", "long"),
+					resource.TestCheckResourceAttr("bigip_net_trunk.test-trunk", "distribution_hash", "src-dst-ipport"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_TRUNK_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckTrunkExists(TEST_TRUNK_NAME, true),
+					resource.TestCheckResourceAttr("bigip_net_trunk.test-trunk", "interfaces.#", "3"),
+					resource.TestCheckResourceAttr("bigip_net_trunk.test-trunk", "lacp", "true"),
+					resource.TestCheckResourceAttr("bigip_net_trunk.test-trunk", "lacp_mode", "passive"),
+					resource.TestCheckResourceAttr("bigip_net_trunk.test-trunk", "lacp_timeout", "short"),
+					resource.TestCheckResourceAttr("bigip_net_trunk.test-trunk", "distribution_hash", "src-dst-mac"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipNetTrunk_import(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckTrunksDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_TRUNK_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckTrunkExists(TEST_TRUNK_NAME, true),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_net_trunk.test-trunk",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckTrunkExists(name string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		trunk, err := client.GetTrunk(name)
+		if err != nil {
+			return err
+		}
+		if exists && trunk == nil {
+			return fmt.Errorf("Trunk %s does not exist.", name)
+		}
+		if !exists && trunk != nil {
+			return fmt.Errorf("Trunk %s exists.", name)
+		}
+		return nil
+	}
+}
+
+func testCheckTrunksDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_net_trunk" {
+			continue
+		}
+
+		name := rs.Primary.ID
+		trunk, err := client.GetTrunk(name)
+		if err != nil {
+			return err
+		}
+		if trunk != nil {
+			return fmt.Errorf("Trunk %s not destroyed.", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package bigip
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipNetVlan() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipNetVlanCreate,
+		Read:   resourceBigipNetVlanRead,
+		Update: resourceBigipNetVlanUpdate,
+		Delete: resourceBigipNetVlanDelete,
+		Exists: resourceBigipNetVlanExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceBigipNetVlanImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the VLAN",
+				ForceNew:     true,
+				ValidateFunc: validateF5Name,
+			},
+
+			"tag": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "VLAN ID (tag). The BIG-IP assigns one if not specified",
+				ValidateFunc: validateIntRange(1, 4094),
+			},
+
+			"mtu": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1500,
+				Description:  "Maximum transmission unit for the VLAN",
+				ValidateFunc: validateIntRange(576, 9198),
+			},
+
+			"interfaces": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Interfaces or trunks that are members of the VLAN",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"vlanport": &schema.Schema{
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Interface or trunk name, e.g. 1.1",
+						},
+						"tagged": &schema.Schema{
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether traffic on the interface is tagged with the VLAN ID",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceBigipNetVlanCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := fullPath(client.Partition, d.Get("name").(string))
+
+	log.Println("[INFO] Creating VLAN " + name)
+	d.SetId(name)
+	err := client.Transaction(func(tx *bigip.BigIP) error {
+		err := tx.CreateVlan(name, d.Get("tag").(int))
+		if err != nil {
+			return err
+		}
+		err = modifyVlan(tx, d)
+		if err != nil {
+			return err
+		}
+		// the VLAN is new, so every interface is added
+		return updateVlanInterfaces(tx, name, flattenVlanInterfaces(nil), d.Get("interfaces").(*schema.Set))
+	})
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceBigipNetVlanRead(d, meta)
+}
+
+func resourceBigipNetVlanRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	log.Println("[INFO] Reading VLAN " + name)
+
+	vlan, err := client.GetVlan(name)
+	if err != nil {
+		return err
+	}
+	interfaces, err := client.GetVlanInterfaces(name)
+	if err != nil {
+		return err
+	}
+
+	d.Set("name", name)
+	d.Set("tag", vlan.Tag)
+	d.Set("mtu", vlan.MTU)
+	d.Set("interfaces", flattenVlanInterfaces(interfaces.VlanInterfaces))
+
+	return nil
+}
+
+func resourceBigipNetVlanExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Checking VLAN " + name + " exists.")
+
+	vlan, err := client.GetVlan(name)
+	if err != nil {
+		return false, err
+	}
+
+	if vlan == nil {
+		d.SetId("")
+	}
+
+	return vlan != nil, nil
+}
+
+func resourceBigipNetVlanUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+
+	err := modifyVlan(client, d)
+	if err != nil {
+		return err
+	}
+
+	//members
+	interfaces, err := client.GetVlanInterfaces(name)
+	if err != nil {
+		return err
+	}
+
+	return updateVlanInterfaces(client, name, flattenVlanInterfaces(interfaces.VlanInterfaces), d.Get("interfaces").(*schema.Set))
+}
+
+func modifyVlan(client *bigip.BigIP, d *schema.ResourceData) error {
+	vlan := &bigip.Vlan{
+		Tag: d.Get("tag").(int),
+		MTU: d.Get("mtu").(int),
+	}
+
+	return client.ModifyVlan(d.Id(), vlan)
+}
+
+// updateVlanInterfaces removes the interfaces of VLAN name that aren't in
+// incoming and adds those that aren't in existing.
+func updateVlanInterfaces(client *bigip.BigIP, name string, existing, incoming *schema.Set) error {
+	for _, i := range existing.Difference(incoming).List() {
+		iface := i.(map[string]interface{})
+		err := client.DeleteInterfaceFromVlan(name, iface["vlanport"].(string))
+		if err != nil {
+			return err
+		}
+	}
+	for _, i := range incoming.Difference(existing).List() {
+		iface := i.(map[string]interface{})
+		err := client.AddInterfaceToVlan(name, iface["vlanport"].(string), iface["tagged"].(bool))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceBigipNetVlanDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting VLAN " + name)
+
+	return client.DeleteVlan(name)
+}
+
+func resourceBigipNetVlanImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
+	return []*schema.ResourceData{d}, nil
+}
+
+// Convert VLAN members into the interfaces set
+func flattenVlanInterfaces(interfaces []bigip.VlanInterface) *schema.Set {
+	s := resourceBigipNetVlan().Schema["interfaces"]
+	set := schema.NewSet(schema.HashResource(s.Elem.(*schema.Resource)), nil)
+	for _, i := range interfaces {
+		set.Add(map[string]interface{}{
+			"vlanport": i.Name,
+			"tagged":   i.Tagged,
+		})
+	}
+	return set
+}
@@ -0,0 +1,157 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/scottdware/go-bigip"
+)
+
+var TEST_VLAN_NAME = fmt.Sprintf("/%s/test-vlan", TEST_PARTITION)
+
+var TEST_VLAN_RESOURCE = `
+resource "bigip_net_vlan" "test-vlan" {
+	name = "` + TEST_VLAN_NAME + `"
+	tag = 101
+	mtu = 1500
+	interfaces = {
+		vlanport = "1.1"
+		tagged = true
+	}
+}
+`
+
+var TEST_VLAN_RESOURCE_UPDATED = `
+resource "bigip_net_vlan" "test-vlan" {
+	name = "` + TEST_VLAN_NAME + `"
+	tag = 102
+	mtu = 9000
+	interfaces = {
+		vlanport = "1.1"
+		tagged = false
+	}
+	interfaces = {
+		vlanport = "1.2"
+		tagged = true
+	}
+}
+`
+
+func TestBigipNetVlan_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckVlansDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_VLAN_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVlanExists(TEST_VLAN_NAME, true),
+					testCheckVlanInterface(TEST_VLAN_NAME, "1.1", true),
+					resource.TestCheckResourceAttr("bigip_net_vlan.test-vlan", "name", TEST_VLAN_NAME),
+					resource.TestCheckResourceAttr("bigip_net_vlan.test-vlan", "tag", "101"),
+					resource.TestCheckResourceAttr("bigip_net_vlan.test-vlan", "mtu", "1500"),
+					resource.TestCheckResourceAttr("bigip_net_vlan.test-vlan", "interfaces.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_VLAN_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVlanExists(TEST_VLAN_NAME, true),
+					testCheckVlanInterface(TEST_VLAN_NAME, "1.1", false),
+					testCheckVlanInterface(TEST_VLAN_NAME, "1.2", true),
+					resource.TestCheckResourceAttr("bigip_net_vlan.test-vlan", "tag", "102"),
+					resource.TestCheckResourceAttr("bigip_net_vlan.test-vlan", "mtu", "9000"),
+					resource.TestCheckResourceAttr("bigip_net_vlan.test-vlan", "interfaces.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipNetVlan_import(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckVlansDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_VLAN_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckVlanExists(TEST_VLAN_NAME, true),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_net_vlan.test-vlan",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testCheckVlanExists(name string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		vlan, err := client.GetVlan(name)
+		if err != nil {
+			return err
+		}
+		if exists && vlan == nil {
+			return fmt.Errorf("VLAN %s does not exist.", name)
+		}
+		if !exists && vlan != nil {
+			return fmt.Errorf("VLAN %s exists.", name)
+		}
+		return nil
+	}
+}
+
+func testCheckVlanInterface(name, iface string, tagged bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		interfaces, err := client.GetVlanInterfaces(name)
+		if err != nil {
+			return err
+		}
+
+		for _, i := range interfaces.VlanInterfaces {
+			if i.Name == iface {
+				if i.Tagged != tagged {
+					return fmt.Errorf("Interface %s on VLAN %s has tagged=%t", iface, name, i.Tagged)
+				}
+				return nil
+			}
+		}
+
+		return fmt.Errorf("Interface %s not found in VLAN %s", iface, name)
+	}
+}
+
+func testCheckVlansDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_net_vlan" {
+			continue
+		}
+
+		name := rs.Primary.ID
+		vlan, err := client.GetVlan(name)
+		if err != nil {
+			return err
+		}
+		if vlan != nil {
+			return fmt.Errorf("VLAN %s not destroyed.", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package bigip
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipSysSSLCertificate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipSysSSLCertificateCreate,
+		Read:   resourceBigipSysSSLCertificateRead,
+		Delete: resourceBigipSysSSLCertificateDelete,
+		Exists: resourceBigipSysSSLCertificateExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceBigipSysSSLCertificateImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the certificate",
+				ForceNew:     true,
+				ValidateFunc: validateF5Name,
+			},
+
+			"content": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "PEM encoded certificate",
+				ForceNew:     true,
+				StateFunc:    sslFileChecksum,
+				ValidateFunc: validatePEM("CERTIFICATE"),
+			},
+
+			"subject": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Subject of the certificate",
+			},
+
+			"issuer": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Issuer of the certificate",
+			},
+
+			"expiration_date": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Expiry of the certificate in seconds since the epoch",
+			},
+
+			"expiration_string": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiry of the certificate, e.g. Jan  1 00:00:00 2030 GMT",
+			},
+
+			"fingerprint": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Fingerprint of the certificate",
+			},
+
+			"serial_number": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Serial number of the certificate",
+			},
+		},
+	}
+}
+
+// Checksum of a file in the format the BIG-IP reports for installed certificates
+// and keys, so state holds the checksum rather than the PEM itself
+func sslFileChecksum(content interface{}) string {
+	data := []byte(content.(string))
+	return fmt.Sprintf("SHA1:%d:%x", len(data), sha1.Sum(data))
+}
+
+// Name of the file a certificate or key is uploaded to before it is installed,
+// e.g. Common_www.example.com.3f2a9c1e7b4d8a60.crt. The random part keeps a
+// certificate and key of the same name, created in parallel, from overwriting
+// each other's upload
+func sslUploadFileName(name, extension string) (string, error) {
+	random := make([]byte, 8)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	partition, base := parseF5Identifier(name)
+	if partition != "" {
+		base = partition + "_" + base
+	}
+	return fmt.Sprintf("%s.%s.%s", base, hex.EncodeToString(random), extension), nil
+}
+
+// Upload a certificate or key and install it with install, removing the uploaded
+// file afterwards whether it was installed or not
+func installSSLFile(client *bigip.BigIP, name, extension, content string, install func(path string) error) error {
+	filename, err := sslUploadFileName(name, extension)
+	if err != nil {
+		return err
+	}
+	path, err := client.UploadBytes([]byte(content), filename)
+	if err != nil {
+		return err
+	}
+
+	err = install(path)
+	if rmErr := client.DeleteUploadedFile(path); rmErr != nil {
+		log.Printf("[WARN] Error deleting uploaded file %s: %s", path, rmErr)
+	}
+	return err
+}
+
+func resourceBigipSysSSLCertificateCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := fullPath(client.Partition, d.Get("name").(string))
+	log.Println("[INFO] Uploading certificate " + name)
+
+	err := installSSLFile(client, name, "crt", d.Get("content").(string), func(path string) error {
+		log.Println("[INFO] Installing certificate " + name)
+		return client.InstallCertificate(name, path)
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId(name)
+
+	return resourceBigipSysSSLCertificateRead(d, meta)
+}
+
+func resourceBigipSysSSLCertificateRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Reading certificate " + name)
+
+	cert, err := client.GetCertificate(name)
+	if err != nil {
+		return err
+	}
+	if cert == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", name)
+	d.Set("content", cert.Checksum)
+	d.Set("subject", cert.Subject)
+	d.Set("issuer", cert.Issuer)
+	d.Set("expiration_date", int(cert.ExpirationDate))
+	d.Set("expiration_string", cert.ExpirationString)
+	d.Set("fingerprint", cert.Fingerprint)
+	d.Set("serial_number", cert.SerialNumber)
+
+	return nil
+}
+
+func resourceBigipSysSSLCertificateExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Checking certificate " + name + " exists.")
+
+	cert, err := client.GetCertificate(name)
+	if err != nil {
+		return false, err
+	}
+
+	if cert == nil {
+		d.SetId("")
+	}
+
+	return cert != nil, nil
+}
+
+func resourceBigipSysSSLCertificateDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting certificate " + name)
+
+	return client.DeleteCertificate(name)
+}
+
+func resourceBigipSysSSLCertificateImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
+	return []*schema.ResourceData{d}, nil
+}
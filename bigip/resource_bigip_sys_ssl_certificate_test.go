@@ -0,0 +1,182 @@
+package bigip
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/scottdware/go-bigip"
+)
+
+var TEST_SSL_CERT_NAME = fmt.Sprintf("/%s/test-cert.crt", TEST_PARTITION)
+var TEST_SSL_CERT_EXPIRY = time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+var testSSLKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+var TEST_SSL_KEY_PEM = string(pem.EncodeToMemory(&pem.Block{
+	Type:  "RSA PRIVATE KEY",
+	Bytes: x509.MarshalPKCS1PrivateKey(testSSLKey),
+}))
+var TEST_SSL_CERT_PEM = testGenerateCertificate("test1.example.com", 1)
+var TEST_SSL_CERT_PEM_UPDATED = testGenerateCertificate("test2.example.com", 2)
+
+var TEST_SSL_CERT_RESOURCE = testSSLCertificateResource(TEST_SSL_CERT_PEM)
+var TEST_SSL_CERT_RESOURCE_UPDATED = testSSLCertificateResource(TEST_SSL_CERT_PEM_UPDATED)
+
+// Self-signed certificate for testSSLKey, PEM encoded
+func testGenerateCertificate(commonName string, serial int64) string {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"Example"}},
+		NotBefore:    time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     TEST_SSL_CERT_EXPIRY,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &testSSLKey.PublicKey, testSSLKey)
+	if err != nil {
+		panic(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func testSSLCertificateResource(content string) string {
+	return `
+resource "bigip_sys_ssl_certificate" "test-cert" {
+	name = "` + TEST_SSL_CERT_NAME + `"
+	content = <<EOF
+` + content + `EOF
+}
+`
+}
+
+func TestBigipSysSSLCertificate_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckSSLCertificatesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_SSL_CERT_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSSLCertificateExists(TEST_SSL_CERT_NAME, true),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_certificate.test-cert", "name", TEST_SSL_CERT_NAME),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_certificate.test-cert", "content", sslFileChecksum(TEST_SSL_CERT_PEM)),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_certificate.test-cert", "subject", "CN=test1.example.com,O=Example"),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_certificate.test-cert", "issuer", "CN=test1.example.com,O=Example"),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_certificate.test-cert", "expiration_date", fmt.Sprint(TEST_SSL_CERT_EXPIRY.Unix())),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_certificate.test-cert", "expiration_string", "Jan  1 00:00:00 2030 GMT"),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_certificate.test-cert", "serial_number", "1"),
+					resource.TestCheckResourceAttrSet("bigip_sys_ssl_certificate.test-cert", "fingerprint"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_SSL_CERT_RESOURCE_UPDATED,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSSLCertificateExists(TEST_SSL_CERT_NAME, true),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_certificate.test-cert", "content", sslFileChecksum(TEST_SSL_CERT_PEM_UPDATED)),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_certificate.test-cert", "subject", "CN=test2.example.com,O=Example"),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_certificate.test-cert", "serial_number", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipSysSSLCertificate_import(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckSSLCertificatesDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_SSL_CERT_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSSLCertificateExists(TEST_SSL_CERT_NAME, true),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "bigip_sys_ssl_certificate.test-cert",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestBigipSysSSLCertificate_clientSSLProfile(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers: testAccProviders,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testCheckSSLCertificatesDestroyed,
+			testCheckSSLKeysDestroyed,
+			testCheckClientSSLProfilesDestroyed,
+		),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_SSL_CERT_RESOURCE + TEST_SSL_KEY_RESOURCE + `
+resource "bigip_ltm_profile_client_ssl" "test-client-ssl" {
+	name = "` + TEST_CLIENT_SSL_NAME + `"
+	cert = "${bigip_sys_ssl_certificate.test-cert.name}"
+	key = "${bigip_sys_ssl_key.test-key.name}"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckClientSSLProfileExists(TEST_CLIENT_SSL_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-client-ssl", "cert", TEST_SSL_CERT_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-client-ssl", "key", TEST_SSL_KEY_NAME),
+				),
+			},
+		},
+	})
+}
+
+func testCheckSSLCertificateExists(name string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		cert, err := client.GetCertificate(name)
+		if err != nil {
+			return err
+		}
+		if exists && cert == nil {
+			return fmt.Errorf("Certificate %s does not exist.", name)
+		}
+		if !exists && cert != nil {
+			return fmt.Errorf("Certificate %s exists.", name)
+		}
+		return nil
+	}
+}
+
+func testCheckSSLCertificatesDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_sys_ssl_certificate" {
+			continue
+		}
+
+		name := rs.Primary.ID
+		cert, err := client.GetCertificate(name)
+		if err != nil {
+			return err
+		}
+		if cert != nil {
+			return fmt.Errorf("Certificate %s not destroyed.", name)
+		}
+	}
+	return nil
+}
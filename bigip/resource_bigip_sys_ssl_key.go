@@ -0,0 +1,141 @@
+package bigip
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+)
+
+func resourceBigipSysSSLKey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipSysSSLKeyCreate,
+		Read:   resourceBigipSysSSLKeyRead,
+		Delete: resourceBigipSysSSLKeyDelete,
+		Exists: resourceBigipSysSSLKeyExists,
+		Importer: &schema.ResourceImporter{
+			State: resourceBigipSysSSLKeyImporter,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the key",
+				ForceNew:     true,
+				ValidateFunc: validateF5Name,
+			},
+
+			"content": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				Description:  "PEM encoded private key",
+				ForceNew:     true,
+				StateFunc:    sslFileChecksum,
+				ValidateFunc: validatePEM("PRIVATE KEY"),
+			},
+
+			"passphrase": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Passphrase the key is encrypted with",
+				ForceNew:    true,
+			},
+
+			"key_type": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the key, e.g. rsa-private",
+			},
+
+			"key_size": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size of the key in bits",
+			},
+
+			"security_type": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "How the key is stored, e.g. normal or password",
+			},
+		},
+	}
+}
+
+func resourceBigipSysSSLKeyCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := fullPath(client.Partition, d.Get("name").(string))
+	log.Println("[INFO] Uploading key " + name)
+
+	err := installSSLFile(client, name, "key", d.Get("content").(string), func(path string) error {
+		log.Println("[INFO] Installing key " + name)
+		return client.InstallKey(name, path, d.Get("passphrase").(string))
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId(name)
+
+	return resourceBigipSysSSLKeyRead(d, meta)
+}
+
+func resourceBigipSysSSLKeyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Reading key " + name)
+
+	key, err := client.GetKey(name)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", name)
+	d.Set("content", key.Checksum)
+	d.Set("key_type", key.KeyType)
+	d.Set("key_size", key.KeySize)
+	d.Set("security_type", key.SecurityType)
+
+	return nil
+}
+
+func resourceBigipSysSSLKeyExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Checking key " + name + " exists.")
+
+	key, err := client.GetKey(name)
+	if err != nil {
+		return false, err
+	}
+
+	if key == nil {
+		d.SetId("")
+	}
+
+	return key != nil, nil
+}
+
+func resourceBigipSysSSLKeyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigip.BigIP)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting key " + name)
+
+	return client.DeleteKey(name)
+}
+
+func resourceBigipSysSSLKeyImporter(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigip.BigIP)
+	d.SetId(fullPath(client.Partition, d.Id()))
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,204 @@
+package bigip
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/scottdware/go-bigip"
+)
+
+var TEST_SSL_KEY_NAME = fmt.Sprintf("/%s/test-key.key", TEST_PARTITION)
+var TEST_SSL_KEY_PASSPHRASE = "secret"
+
+var TEST_SSL_KEY_ENCRYPTED_PEM = testEncryptKey(TEST_SSL_KEY_PASSPHRASE)
+
+var TEST_SSL_KEY_RESOURCE = testSSLKeyResource(TEST_SSL_KEY_PEM, "")
+var TEST_SSL_KEY_ENCRYPTED_RESOURCE = testSSLKeyResource(TEST_SSL_KEY_ENCRYPTED_PEM, TEST_SSL_KEY_PASSPHRASE)
+
+// testSSLKey, PEM encoded and encrypted with passphrase
+func testEncryptKey(passphrase string) string {
+	block, err := x509.EncryptPEMBlock(rand.Reader, "RSA PRIVATE KEY",
+		x509.MarshalPKCS1PrivateKey(testSSLKey), []byte(passphrase), x509.PEMCipherAES256)
+	if err != nil {
+		panic(err)
+	}
+	return string(pem.EncodeToMemory(block))
+}
+
+func testSSLKeyResource(content, passphrase string) string {
+	return `
+resource "bigip_sys_ssl_key" "test-key" {
+	name = "` + TEST_SSL_KEY_NAME + `"
+	passphrase = "` + passphrase + `"
+	content = <<EOF
+` + content + `EOF
+}
+`
+}
+
+func TestBigipSysSSLKey_create(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckSSLKeysDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_SSL_KEY_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSSLKeyExists(TEST_SSL_KEY_NAME, true),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_key.test-key", "name", TEST_SSL_KEY_NAME),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_key.test-key", "content", sslFileChecksum(TEST_SSL_KEY_PEM)),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_key.test-key", "key_type", "rsa-private"),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_key.test-key", "key_size", "2048"),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_key.test-key", "security_type", "normal"),
+				),
+			},
+			resource.TestStep{
+				Config: TEST_SSL_KEY_ENCRYPTED_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSSLKeyExists(TEST_SSL_KEY_NAME, true),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_key.test-key", "content", sslFileChecksum(TEST_SSL_KEY_ENCRYPTED_PEM)),
+					resource.TestCheckResourceAttr("bigip_sys_ssl_key.test-key", "security_type", "password"),
+				),
+			},
+		},
+	})
+}
+
+func TestBigipSysSSLKey_wrongPassphrase(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckSSLKeysDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      testSSLKeyResource(TEST_SSL_KEY_ENCRYPTED_PEM, "wrong"),
+				ExpectError: regexp.MustCompile("decryption"),
+			},
+		},
+	})
+}
+
+// A certificate and key of the same name are uploaded to different files, so
+// creating them in parallel installs each from its own PEM, and the uploads are
+// removed once installed or rejected.
+func TestBigipSysSSLKey_uploads(t *testing.T) {
+	mock := newMockBigip("admin", "admin")
+	defer mock.Close()
+	client := bigip.NewSession(mock.URL, "admin", "admin", nil)
+
+	name := "/Common/www.example.com"
+	cert := resourceBigipSysSSLCertificate().Data(nil)
+	cert.Set("name", name)
+	cert.Set("content", TEST_SSL_CERT_PEM)
+	key := resourceBigipSysSSLKey().Data(nil)
+	key.Set("name", name)
+	key.Set("content", TEST_SSL_KEY_PEM)
+
+	errs := make(chan error, 2)
+	go func() { errs <- resourceBigipSysSSLCertificateCreate(cert, client) }()
+	go func() { errs <- resourceBigipSysSSLKeyCreate(key, client) }()
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if c, err := client.GetCertificate(name); err != nil || c == nil || c.Checksum != sslFileChecksum(TEST_SSL_CERT_PEM) {
+		t.Errorf("Certificate %s was not installed from its own file: %v, %v", name, c, err)
+	}
+	if k, err := client.GetKey(name); err != nil || k == nil || k.Checksum != sslFileChecksum(TEST_SSL_KEY_PEM) {
+		t.Errorf("Key %s was not installed from its own file: %v, %v", name, k, err)
+	}
+	if files := mock.uploadedFiles(); len(files) != 0 {
+		t.Errorf("Uploaded files %v were not removed", files)
+	}
+
+	key = resourceBigipSysSSLKey().Data(nil)
+	key.Set("name", "/Common/encrypted.example.com")
+	key.Set("content", TEST_SSL_KEY_ENCRYPTED_PEM)
+	key.Set("passphrase", "wrong")
+	if err := resourceBigipSysSSLKeyCreate(key, client); err == nil {
+		t.Errorf("Key with the wrong passphrase was installed")
+	}
+	if files := mock.uploadedFiles(); len(files) != 0 {
+		t.Errorf("Uploaded files %v were not removed after the install failed", files)
+	}
+
+	first, _ := sslUploadFileName(name, "key")
+	second, _ := sslUploadFileName(name, "key")
+	if first == second || !regexp.MustCompile(`^Common_www\.example\.com\.[0-9a-f]{16}\.key$`).MatchString(first) {
+		t.Errorf("Upload file names %s and %s are not unique names for a key", first, second)
+	}
+}
+
+func TestBigipSysSSLKey_import(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckSSLKeysDestroyed,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: TEST_SSL_KEY_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckSSLKeyExists(TEST_SSL_KEY_NAME, true),
+				),
+			},
+			resource.TestStep{
+				ResourceName:            "bigip_sys_ssl_key.test-key",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"passphrase"},
+			},
+		},
+	})
+}
+
+func testCheckSSLKeyExists(name string, exists bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigip.BigIP)
+
+		key, err := client.GetKey(name)
+		if err != nil {
+			return err
+		}
+		if exists && key == nil {
+			return fmt.Errorf("Key %s does not exist.", name)
+		}
+		if !exists && key != nil {
+			return fmt.Errorf("Key %s exists.", name)
+		}
+		return nil
+	}
+}
+
+func testCheckSSLKeysDestroyed(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_sys_ssl_key" {
+			continue
+		}
+
+		name := rs.Primary.ID
+		key, err := client.GetKey(name)
+		if err != nil {
+			return err
+		}
+		if key != nil {
+			return fmt.Errorf("Key %s not destroyed.", name)
+		}
+	}
+	return nil
+}
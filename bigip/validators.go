@@ -1,10 +1,16 @@
 package bigip
 
 import (
+	"encoding/hex"
+	"encoding/pem"
 	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/scottdware/go-bigip"
+	"net"
 	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
 )
 
 //Validate the incoming set only contains values from the specified set
@@ -29,7 +35,141 @@ func validateStringValue(values []string) schema.SchemaValidateFunc {
 	}
 }
 
+func validateIntRange(min, max int) schema.SchemaValidateFunc {
+	return func(value interface{}, field string) (ws []string, errors []error) {
+		v := value.(int)
+		if v < min || v > max {
+			errors = append(errors, fmt.Errorf("%q must be between %d and %d", field, min, max))
+		}
+		return
+	}
+}
+
+//Validate a string attribute that takes either one of the given keywords or a
+//number in range, e.g. an IP ToS of pass-through or 0-255
+func validateKeywordOrIntRange(keywords []string, min, max int) schema.SchemaValidateFunc {
+	return func(value interface{}, field string) (ws []string, errors []error) {
+		for _, k := range keywords {
+			if k == value.(string) {
+				return
+			}
+		}
+		if v, err := strconv.Atoi(value.(string)); err != nil || v < min || v > max {
+			errors = append(errors, fmt.Errorf("%q must be one of %v or a number between %d and %d", field, keywords, min, max))
+		}
+		return
+	}
+}
+
+//Validate an address with a CIDR mask, optionally inside a route domain (e.g. 10.1.1.1%2/24)
+func validateCIDR(value interface{}, field string) (ws []string, errors []error) {
+	address := regexp.MustCompile("%\\d+").ReplaceAllString(value.(string), "")
+	if _, _, err := net.ParseCIDR(address); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be an address with a CIDR mask, e.g. 10.1.1.1/24", field))
+	}
+	return
+}
+
+//Validate an IP address, optionally inside a route domain (e.g. 10.1.1.1%2)
+func validateIPAddress(value interface{}, field string) (ws []string, errors []error) {
+	address, routeDomain := splitRouteDomain(value.(string))
+	if _, err := strconv.Atoi(routeDomain); net.ParseIP(address) == nil || (address != value.(string) && err != nil) {
+		errors = append(errors, fmt.Errorf("%q must be an IP address, optionally with a route domain, e.g. 10.1.1.1%%2", field))
+	}
+	return
+}
+
+//Validate an address, optionally qualified with its partition, e.g. /Common/10.1.1.1
+//or 10.1.1.1
+func validatePartitionedAddress(value interface{}, field string) (ws []string, errors []error) {
+	partition, address := parseF5Identifier(value.(string))
+	if address == "" || (partition == "" && strings.HasPrefix(value.(string), "/")) {
+		errors = append(errors, fmt.Errorf("%q must match /Partition/Address or Address, e.g. /Common/10.1.1.1 or 10.1.1.1", field))
+		return
+	}
+	return validateIPAddress(address, field)
+}
+
+//Validate a virtual address name: an address qualified with its partition, as
+//virtual servers name the addresses they create, e.g. /Common/2001:db8::1%2, or
+//any other object name
+func validateVirtualAddressName(value interface{}, field string) (ws []string, errors []error) {
+	if _, errs := validatePartitionedAddress(value, field); len(errs) == 0 {
+		return
+	}
+	return validateF5Name(value, field)
+}
+
+//Validate a route destination: a network in CIDR notation or default/default-inet6
+func validateRouteNetwork(value interface{}, field string) (ws []string, errors []error) {
+	match, _ := regexp.MatchString("^default(-inet6)?(%\\d+)?$", value.(string))
+	if match {
+		return
+	}
+	return validateCIDR(value, field)
+}
+
+//Validate a self IP port lockdown entry: all, default or protocol:port.
+//none is left out because an empty list already means none
+func validatePortLockdown(value interface{}, field string) (ws []string, errors []error) {
+	if value.(string) == "none" {
+		errors = append(errors, fmt.Errorf("%q can't be none, leave it empty for none", field))
+		return
+	}
+	match, _ := regexp.MatchString("^(all|default|[a-z0-9-]+:\\d{1,5})$", value.(string))
+	if !match {
+		errors = append(errors, fmt.Errorf("%q must be all, default or protocol:port, e.g. tcp:443", field))
+	}
+	return
+}
+
+//Validate the name of an object that lives outside any partition, e.g. a trunk
+func validateUnpartitionedName(value interface{}, field string) (ws []string, errors []error) {
+	match, _ := regexp.MatchString("^[\\w.-]+$", value.(string))
+	if !match {
+		errors = append(errors, fmt.Errorf("%q must contain only letters, numbers or [._-] and no partition, e.g. trunk1", field))
+	}
+	return
+}
+
+//Validate a monitor destination: address:port where either may be *, e.g. *:8080.
+//IPv6 addresses use . before the port
+func validateMonitorDestination(value interface{}, field string) (ws []string, errors []error) {
+	match, _ := regexp.MatchString("^(\\*|[\\d.]+(%\\d+)?):(\\*|\\d{1,5})$|^(\\*|[0-9a-fA-F:]+(%\\d+)?)\\.(\\*|\\d{1,5})$", value.(string))
+	if !match {
+		errors = append(errors, fmt.Errorf("%q must be address:port, using * for any, e.g. *:8080 or 10.1.1.1:80", field))
+	}
+	return
+}
+
+//Validate a pool member name: a node, optionally qualified with its partition, and
+//a port, e.g. /Common/web1:80, web1:80 or /Common/2001:db8::1.80 (IPv6 addresses
+//use . before the port)
+func validatePoolMemberName(value interface{}, field string) (ws []string, errors []error) {
+	partition, member := parseF5Identifier(value.(string))
+	_, node, port, err := bigip.ParseDestination(member)
+	match, _ := regexp.MatchString("^[\\w.%:-]+$", node)
+	if err != nil || !match || bigip.FormatDestination(partition, node, port) != value.(string) {
+		errors = append(errors, fmt.Errorf("%q must match /Partition/Node:Port or Node:Port, e.g. /Common/web1:80 or web1:80", field))
+	}
+	return
+}
+
+//Validate an object name, optionally qualified with its partition, e.g. my-pool
+//or "/Common/my-pool". Names without a partition resolve into the provider's partition
 func validateF5Name(value interface{}, field string) (ws []string, errors []error) {
+	return validateF5Names(value, field, "^(/[\\w_\\-.]+/)?[\\w_\\-.]+$",
+		"%q must match /Partition/Name or Name and contain letters, numbers or [._-]. e.g. /Common/my-pool or my-pool")
+}
+
+//Validate an object name that must be qualified with its partition, e.g. a
+//traffic group, which always lives in /Common
+func validateF5FullPath(value interface{}, field string) (ws []string, errors []error) {
+	return validateF5Names(value, field, "^/[\\w_\\-.]+/[\\w_\\-.]+$",
+		"%q must match /Partition/Name and contain letters, numbers or [._-]. e.g. /Common/my-pool")
+}
+
+func validateF5Names(value interface{}, field, pattern, message string) (ws []string, errors []error) {
 	var values []string
 	switch value.(type) {
 	case *schema.Set:
@@ -49,10 +189,40 @@ func validateF5Name(value interface{}, field string) (ws []string, errors []erro
 	}
 
 	for _, v := range values {
-		match, _ := regexp.MatchString("^/[\\w_\\-.]+/[\\w_\\-.]+$", v)
+		match, _ := regexp.MatchString(pattern, v)
 		if !match {
-			errors = append(errors, fmt.Errorf("%q must match /Partition/Name and contain letters, numbers or [._-]. e.g. /Common/my-pool", field))
+			errors = append(errors, fmt.Errorf(message, field))
 		}
 	}
 	return
 }
+
+//Validate a partition name, e.g. Common
+func validatePartition(value interface{}, field string) (ws []string, errors []error) {
+	match, _ := regexp.MatchString("^[\\w.-]+$", value.(string))
+	if !match {
+		errors = append(errors, fmt.Errorf("%q must be a partition name without slashes, e.g. Common", field))
+	}
+	return
+}
+
+//Validate PEM content that starts with a block of the given type. A suffix matches
+//every variant, e.g. PRIVATE KEY matches RSA PRIVATE KEY and EC PRIVATE KEY
+func validatePEM(blockType string) schema.SchemaValidateFunc {
+	return func(value interface{}, field string) (ws []string, errors []error) {
+		block, _ := pem.Decode([]byte(value.(string)))
+		if block == nil || !strings.HasSuffix(block.Type, blockType) {
+			errors = append(errors, fmt.Errorf("%q must be PEM encoded and start with a %s block", field, blockType))
+		}
+		return
+	}
+}
+
+//Validate a SHA-256 fingerprint: 64 hex digits, optionally separated by colons
+func validateSHA256Fingerprint(value interface{}, field string) (ws []string, errors []error) {
+	fingerprint := normalizeFingerprint(value.(string))
+	if _, err := hex.DecodeString(fingerprint); err != nil || len(fingerprint) != 64 {
+		errors = append(errors, fmt.Errorf("%q must be a SHA-256 fingerprint, e.g. AB:CD:...: %s", field, value))
+	}
+	return
+}